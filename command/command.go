@@ -2,26 +2,26 @@ package command
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/fake"
+	"io"
 	"io/ioutil"
+	"math/rand"
 	"os"
-	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"text/template"
-	"time"
 )
 
-// InitResume writes a new, empty resume data file to the destination specified by the filename argument.  That
-// filename must have an extension of ".xml" or ".json", and XML or JSON format will be used accordingly.
+// InitResume writes a new, empty resume data file to the destination specified by the filename argument.  The format
+// is chosen by the filename's extension, from those registered in the data package (e.g. ".xml", ".json", ".yaml",
+// ".yml" or ".toml").  A "data.UnsupportedFormatError" is returned for any other extension.
 func InitResumeFile(filename string) error {
-	if strings.ToLower(path.Ext(filename)) == ".xml" {
-		return data.ToXmlFile(data.NewResumeData(), filename)
-	} else {
-		return data.ToJsonFile(data.NewResumeData(), filename)
-	}
+	return data.ToFile(data.NewResumeData(), filename)
 }
 
 // InitResumeJson returns the JSON text of a new, empty resume data file.
@@ -34,35 +34,275 @@ func InitResumeXml() (string, error) {
 	return data.ToXmlString(data.NewResumeData())
 }
 
-// ConvertResume reads a resume data file in XML or JSON format, and writes that data to another destination file
-// in XML or JSON format.
+// InitResumeYaml returns the YAML text of a new, empty resume data file.
+func InitResumeYaml() (string, error) {
+	return data.ToYamlString(data.NewResumeData())
+}
+
+// InitResumeToml returns the TOML text of a new, empty resume data file.
+func InitResumeToml() (string, error) {
+	return data.ToTomlString(data.NewResumeData())
+}
+
+// ConvertResume reads a resume data file in any registered format (e.g. XML, JSON, YAML or TOML), and writes that
+// data to another destination file in any registered format.  Formats are chosen by the filenames' extensions.  If
+// the input filename has no recognized extension, then its format is detected from its contents (see
+// "data.DetectFormat()").  Foreign formats such as Europass (".europass.xml" or ".europass.json") and HR Open
+// (".hropen.xml" or ".hropen.json") can't represent every field, and "ConvertResumeFileWithOptions()" reports the
+// fields left behind.
 func ConvertResumeFile(inputFilename, outputFilename string) error {
+	_, err := ConvertResumeFileWithOptions(inputFilename, outputFilename, ConvertOptions{})
+	return err
+}
+
+// ConvertOptions holds optional settings for "ConvertResumeFileWithOptions()".  The zero value gives the same
+// behavior as "ConvertResumeFile()".
+type ConvertOptions struct {
+	// Strict rejects input files with unrecognized fields or values of the wrong type, returning a "data.DecodeError"
+	// listing every problem found (see "data.FromJsonFileStrict()").  This only applies to formats supporting strict
+	// decoding (see "data.StrictCodec"), such as XML and JSON.
+	Strict bool
+}
+
+// ConvertReport lists the fields left behind when converting to or from a foreign format such as Europass (see
+// "data.ReportingCodec").  Both reports are empty when converting between native formats.
+type ConvertReport struct {
+	// Import lists the input file's fields that could not be read into resume data.
+	Import data.MappingReport
+	// Export lists the resume data fields that could not be written to the output file.
+	Export data.MappingReport
+}
+
+// Warnings returns one line per field left behind and note in the report, prefixed with what happened to it (e.g.
+// "Not exported: basics: summary"), for the caller to show as it sees fit.
+func (report ConvertReport) Warnings() []string {
+	return append(mappingWarnings(report.Import, "Not imported"), mappingWarnings(report.Export, "Not exported")...)
+}
+
+// ConvertResumeFileWithOptions is a variant of "ConvertResumeFile()", which also accepts options controlling how
+// the input file is read, and returns a report of any fields left behind.  Nothing is written to the console.
+func ConvertResumeFileWithOptions(inputFilename, outputFilename string, options ConvertOptions) (ConvertReport, error) {
+	report := ConvertReport{}
+	if _, err := data.CodecForFilename(outputFilename); err != nil {
+		return report, err
+	}
 	var resume data.ResumeData
 	var err error
-	if strings.ToLower(path.Ext(inputFilename)) == ".xml" {
-		resume, err = data.FromXmlFile(inputFilename)
+	if options.Strict {
+		resume, report.Import, err = data.FromFileStrictWithReport(inputFilename)
 	} else {
-		resume, err = data.FromJsonFile(inputFilename)
+		resume, report.Import, err = data.FromFileWithReport(inputFilename)
+	}
+	if err != nil {
+		return report, err
+	}
+	report.Export, err = data.ToFileWithReport(resume, outputFilename)
+	return report, err
+}
+
+func mappingWarnings(report data.MappingReport, prefix string) []string {
+	var warnings []string
+	for _, field := range report.Unmapped {
+		warnings = append(warnings, fmt.Sprintf("%s: %s", prefix, field))
+	}
+	return append(warnings, report.Notes...)
+}
+
+// MigrateResumeFile upgrades a resume data file in any registered format to the current schema version, rewriting
+// the file in place and in the same format.  Before the file is rewritten, its original contents are copied to a
+// backup file with a ".bak" extension appended.  If the file is already current, then it is left untouched and no
+// backup is made.
+//
+// Foreign formats (see "data.ReportingCodec") are rejected, since they have nowhere to record the schema version...
+// so every run would find them out of date, and rewrite them with whatever they can't represent lost.  Overlays that
+// extend a base file (see "data.ResolveFile()") are rejected too, since rewriting one in full would fill it with
+// blank values that override the base.
+func MigrateResumeFile(filename string) (data.MigrationReport, error) {
+	codec, err := data.DetectFileFormat(filename)
+	if err != nil {
+		return data.MigrationReport{}, err
+	}
+	if _, ok := codec.(data.ReportingCodec); ok {
+		return data.MigrationReport{}, fmt.Errorf("The %s format has no schema version, so \"%s\" can't be migrated.  "+
+			"Convert it to a native format (e.g. JSON or XML) instead.", codec.Name(), filename)
+	}
+	resume, err := data.FromFile(filename)
+	if err != nil {
+		return data.MigrationReport{}, err
+	}
+	if _, ok := resume.Extra[data.ExtendsProperty]; ok {
+		return data.MigrationReport{}, fmt.Errorf("\"%s\" extends a base file, and takes its schema version from there.  "+
+			"Migrate the base file instead.", filename)
+	}
+
+	report, err := data.Migrate(&resume)
+	if err != nil || !report.Migrated() {
+		return report, err
+	}
+
+	originalBytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return report, err
+	}
+	if err := ioutil.WriteFile(filename+".bak", originalBytes, 0644); err != nil {
+		return report, err
+	}
+	// Write in the same format that was read, even if the filename has no recognized extension
+	var buffer bytes.Buffer
+	if err := codec.Encode(resume, &buffer); err != nil {
+		return report, err
+	}
+	return report, ioutil.WriteFile(filename, buffer.Bytes(), 0644)
+}
+
+// ImportLinkedInFile converts the zip archive from LinkedIn's "download your data" feature into a resume data file,
+// in any registered format chosen by the output filename's extension.  The returned report lists the CSV columns
+// that could not be mapped (see "data.FromLinkedInFile()").
+func ImportLinkedInFile(zipFilename, outputFilename string) (data.MappingReport, error) {
+	if _, err := data.CodecForFilename(outputFilename); err != nil {
+		return data.MappingReport{}, err
+	}
+	resume, report, err := data.FromLinkedInFile(zipFilename)
+	if err != nil {
+		return report, err
+	}
+	return report, data.ToFile(resume, outputFilename)
+}
+
+// GenerateResumeFiles writes a set of fake resume data files, for stress-testing templates offline (see
+// "fake.GenerateResumeData()").  The filename pattern contains a "%d" or zero-padded "%0Nd" verb (e.g.
+// "out/resume-%03d.json"), which is replaced by each file's number from 1 to count, and its extension chooses the
+// format.  The verb may only be left out when writing a single file, and a literal percent sign is written "%%".
+// Each file's seed is drawn in turn from a random source seeded with the options' seed, so that the same set of files
+// is written every time, while nearby seeds still produce unrelated sets.  The filenames written are returned.
+func GenerateResumeFiles(filenamePattern string, count int, options fake.GeneratorOptions) ([]string, error) {
+	verbs, err := countFilenameVerbs(filenamePattern)
+	if err != nil {
+		return nil, err
+	}
+	if verbs == 0 && count > 1 {
+		return nil, fmt.Errorf("The filename pattern \"%s\" needs a \"%%d\" verb, to write more than one file", filenamePattern)
+	}
+	if _, err := data.CodecForFilename(filenamePattern); err != nil {
+		return nil, err
+	}
+	seeds := rand.New(rand.NewSource(options.Seed))
+	var filenames []string
+	for number := 1; number <= count; number++ {
+		filename := strings.Replace(filenamePattern, "%%", "%", -1)
+		if verbs > 0 {
+			filename = fmt.Sprintf(filenamePattern, number)
+		}
+		generatorOptions := options
+		generatorOptions.Seed = seeds.Int63()
+		if err := data.ToFile(fake.GenerateResumeData(generatorOptions), filename); err != nil {
+			return filenames, err
+		}
+		filenames = append(filenames, filename)
+	}
+	return filenames, nil
+}
+
+// filenameVerb matches the formatting directives allowed in a "GenerateResumeFiles()" filename pattern.
+var filenameVerb = regexp.MustCompile(`^%(%|(0[1-9][0-9]*)?d)`)
+
+// countFilenameVerbs returns the number of "%d" verbs in a filename pattern, or an error if the pattern has more
+// than one or contains any other directive (which "fmt.Sprintf()" would turn into garbage such as "%!s(int=1)").
+func countFilenameVerbs(filenamePattern string) (int, error) {
+	verbs := 0
+	for index := 0; index < len(filenamePattern); index++ {
+		if filenamePattern[index] != '%' {
+			continue
+		}
+		match := filenameVerb.FindString(filenamePattern[index:])
+		if match == "" {
+			return 0, fmt.Errorf("The filename pattern \"%s\" may only contain a \"%%d\" or \"%%0Nd\" verb, "+
+				"and a literal percent sign must be written \"%%%%\"", filenamePattern)
+		}
+		if match != "%%" {
+			verbs++
+		}
+		index += len(match) - 1
+	}
+	if verbs > 1 {
+		return 0, fmt.Errorf("The filename pattern \"%s\" may only contain one \"%%d\" verb", filenamePattern)
+	}
+	return verbs, nil
+}
+
+// ResolveResumeFile prints the fully resolved form of a resume data file that extends a base file (see
+// "data.ResolveFile()"), in the format registered under the given codec name (e.g. "json" or "yaml").  This shows
+// exactly what an export of the file will see.
+func ResolveResumeFile(inputFilename, codecName string, writer io.Writer) error {
+	codec, ok := data.CodecByName(codecName)
+	if !ok {
+		return fmt.Errorf("Unsupported resume data format \"%s\"", codecName)
 	}
+	resume, err := data.ResolveFile(inputFilename)
 	if err != nil {
 		return err
 	}
+	return codec.Encode(resume, writer)
+}
 
-	if strings.ToLower(path.Ext(outputFilename)) == ".xml" {
-		return data.ToXmlFile(resume, outputFilename)
-	} else {
-		return data.ToJsonFile(resume, outputFilename)
+// DiffFormat selects how "DiffResumeFiles()" prints its change report.
+type DiffFormat int
+
+const (
+	// DiffText prints one change per line (see "data.DiffReport.String()").  This is the default.
+	DiffText DiffFormat = iota
+	// DiffJson prints the report as an indented JSON document, for use by other tools.
+	DiffJson
+)
+
+// DiffResumeFiles compares two resume data files semantically (see "data.Diff()"), and prints a report of the
+// changes from the first file to the second.  Each file may be in any registered format, chosen by its extension or
+// else detected from its contents... so an XML file can be compared with its JSON conversion.  Files extending a
+// base file are resolved first.  The report is also returned, so that callers can tell whether any differences were
+// found.
+func DiffResumeFiles(oldFilename, newFilename string, format DiffFormat, writer io.Writer) (data.DiffReport, error) {
+	oldData, err := fromFile(oldFilename, false)
+	if err != nil {
+		return data.DiffReport{}, err
+	}
+	newData, err := fromFile(newFilename, false)
+	if err != nil {
+		return data.DiffReport{}, err
+	}
+	report := data.Diff(oldData, newData)
+	if format == DiffJson {
+		jsonBytes, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return report, err
+		}
+		_, err = writer.Write(append(jsonBytes, '\n'))
+		return report, err
 	}
+	_, err = io.WriteString(writer, report.String())
+	return report, err
 }
 
 // ExportResumeFile applies a Word 2003 XML template to a resume data file, resulting in a Word document.  This function
 // accepts path and filenames of the resume data file and template file on disk, and the path and filename to which
-// the resume output will be written on disk.
+// the resume output will be written on disk.  The resume data file may be in any registered format, chosen by its
+// extension or else detected from its contents.
 //
 // See:
 //   https://en.wikipedia.org/wiki/Microsoft_Office_XML_formats
 //   https://www.microsoft.com/en-us/download/details.aspx?id=101
 func ExportResumeFile(inputFilename, outputFilename, templateFilename string) error {
+	return ExportResumeFileWithOptions(inputFilename, outputFilename, templateFilename, ExportOptions{})
+}
+
+// ExportResumeFileWithOptions is a variant of "ExportResumeFile()", which also accepts options controlling how the
+// resume data is checked and processed before the template is applied.
+func ExportResumeFileWithOptions(inputFilename, outputFilename, templateFilename string, options ExportOptions) error {
+
+	// Load the resume data first, so that an unsupported input format is reported regardless of the template
+	resumeData, err := fromFile(inputFilename, options.Strict)
+	if err != nil {
+		return err
+	}
 
 	// For some reason, I'm getting blank final results when loading templates via "ParseFiles()"... but it DOES work
 	// when I first read the template contents into a string and load that via "Parse()".
@@ -79,24 +319,10 @@ func ExportResumeFile(inputFilename, outputFilename, templateFilename string) er
 	}
 	templateString := string(templateBytes)
 
-	// Load the resume data
-	var resumeData data.ResumeData
-	extension := strings.ToLower(path.Ext(inputFilename))
-	if extension == ".xml" {
-		resumeData, err = data.FromXmlFile(inputFilename)
-	} else if extension == ".json" {
-		resumeData, err = data.FromJsonFile(inputFilename)
-	} else {
-		err = errors.New("Resume filename must end with \".xml\" or \".json\".")
-	}
-	if err != nil {
-		return nil
-	}
-
 	// Execute the template engine
-	buffer, err := ExportResume(resumeData, templateString)
+	buffer, err := ExportResumeWithOptions(resumeData, templateString, options)
 	if err != nil {
-		return nil
+		return err
 	}
 
 	// Open the output file and write out the resume contents
@@ -113,6 +339,62 @@ func ExportResumeFile(inputFilename, outputFilename, templateFilename string) er
 // accepts the raw resume data structure and the raw template contents directly, returning the generated resume
 // contents in a Writer that can be written to disk or HTTP download.
 func ExportResume(resumeData data.ResumeData, templateContent string) (*bytes.Buffer, error) {
+	return ExportResumeWithOptions(resumeData, templateContent, ExportOptions{})
+}
+
+// ValidationMode controls how an export reacts to the issues found by "data.Validate()".
+type ValidationMode int
+
+const (
+	// ValidationOff skips validation entirely.  This is the default.
+	ValidationOff ValidationMode = iota
+	// ValidationWarn writes each issue to "ExportOptions.Warnings" (if set), and then proceeds with the export.
+	ValidationWarn
+	// ValidationStrict refuses to export resume data having any issues of error severity, returning a
+	// "data.ValidationError".  Issues of warning severity are reported as with ValidationWarn.
+	ValidationStrict
+)
+
+// ExportOptions holds optional settings for "ExportResumeWithOptions()", "ExportResumeToWriter()" and
+// "ExportResumeFileWithOptions()".  The zero value gives the same behavior as "ExportResume()" and
+// "ExportResumeFile()".
+type ExportOptions struct {
+	// Variant selects which tagged entries are included in the export (see "data.Filter()").  The empty selector
+	// includes everything.
+	Variant data.Selector
+	// Redaction removes or masks private information after validation, just before the template is applied (see
+	// "data.ParseRedaction()" for the "public" and "anonymous" profiles).  The empty redaction changes nothing.
+	Redaction data.Redaction
+	// Strict rejects resume data files with unrecognized fields or values of the wrong type, returning a
+	// "data.DecodeError" listing every problem found.  This only applies when reading a file in a format supporting
+	// strict decoding (e.g. XML or JSON), in "ExportResumeFileWithOptions()".
+	Strict bool
+	// Validation checks the resume data with "data.Validate()" before exporting it, and controls whether issues
+	// refuse the export or are only reported.  The default, ValidationOff, skips validation.
+	Validation ValidationMode
+	// Warnings receives one line per validation issue, when validation is enabled.  If nil, then issues that don't
+	// refuse the export are discarded.
+	Warnings io.Writer
+}
+
+// ExportResumeWithOptions is a variant of "ExportResume()", which also accepts options controlling how the resume
+// data is checked and processed before the template is applied.
+func ExportResumeWithOptions(resumeData data.ResumeData, templateContent string, options ExportOptions) (*bytes.Buffer, error) {
+	buffer := bytes.NewBuffer(nil)
+	err := ExportResumeToWriter(resumeData, templateContent, buffer, options)
+	return buffer, err
+}
+
+// ExportResumeToWriter is a variant of "ExportResumeWithOptions()", which renders the generated resume directly into
+// a Writer (e.g. an HTTP response, or standard output) rather than buffering it in memory.  If the template fails
+// partway through, then some output may already have been written.
+func ExportResumeToWriter(resumeData data.ResumeData, templateContent string, writer io.Writer, options ExportOptions) error {
+	resumeData = data.Filter(resumeData, options.Variant)
+	if err := validateForExport(resumeData, options); err != nil {
+		return err
+	}
+	resumeData = data.Redact(resumeData, options.Redaction)
+
 	// Initialize the template engine
 	funcMap := template.FuncMap{
 		"plus1": func(x int) int {
@@ -121,41 +403,29 @@ func ExportResume(resumeData data.ResumeData, templateContent string) (*bytes.Bu
 		"toUpper": func(s string) string {
 			return strings.ToUpper(s)
 		},
-		"YYYY": func(s string) string {
-			const inputFormat = "2006-01-02"
-			dateValue, err := time.Parse(inputFormat, s)
-			if err != nil {
-				return s
-			}
-			const outputFormat = "2006"
-			return dateValue.Format(outputFormat)
+		// The date functions accept any JSON-Resume date (see "data.Date").  Dates with only a year are rendered as
+		// just the year, "present" is rendered as "Present", and unrecognized values are rendered unchanged.
+		"YYYY": func(d data.Date) string {
+			return d.Format("2006")
 		},
-		"MYY": func(s string) string {
-			const inputFormat = "2006-01-02"
-			dateValue, err := time.Parse(inputFormat, s)
-			if err != nil {
-				return s
-			}
-			const outputFormat = "1/06"
-			return dateValue.Format(outputFormat)
+		"MYY": func(d data.Date) string {
+			return d.Format("1/06")
 		},
-		"MYYYY": func(s string) string {
-			const inputFormat = "2006-01-02"
-			dateValue, err := time.Parse(inputFormat, s)
-			if err != nil {
-				return s
-			}
-			const outputFormat = "1/2006"
-			return dateValue.Format(outputFormat)
+		"MYYYY": func(d data.Date) string {
+			return d.Format("1/2006")
 		},
-		"MMMMYYYY": func(s string) string {
-			const inputFormat = "2006-01-02"
-			dateValue, err := time.Parse(inputFormat, s)
-			if err != nil {
-				return s
-			}
-			const outputFormat = "January 2006"
-			return dateValue.Format(outputFormat)
+		"MMMMYYYY": func(d data.Date) string {
+			return d.Format("January 2006")
+		},
+		// The Markdown functions allow summaries, highlights and other text fields to contain inline formatting (see
+		// "parseMarkdown()").  "wordML" renders Word runs to be placed directly inside of a paragraph, and "plainText"
+		// strips the formatting for templates producing other output formats.  Both accept either strings or values
+		// with a "String()" method (e.g. "data.Highlight").
+		"wordML": func(value interface{}) string {
+			return markdownToWordML(fmt.Sprint(value))
+		},
+		"plainText": func(value interface{}) string {
+			return markdownToPlainText(fmt.Sprint(value))
 		},
 		// TODO: I'd love to come up with a reflection-based solution for splitting a slice of any type.  God, I wish Go just had generics...
 		"firstHalfSkills": func(list []data.Skill) []data.Skill {
@@ -177,11 +447,39 @@ func ExportResume(resumeData data.ResumeData, templateContent string) (*bytes.Bu
 			}
 		},
 	}
-	buffer := bytes.NewBuffer(nil)
 	resumeTemplate, err := template.New("resume").Funcs(funcMap).Parse(templateContent)
 	if err != nil {
-		return buffer, err
+		return err
 	}
-	err = resumeTemplate.Execute(buffer, resumeData)
-	return buffer, err
+	return resumeTemplate.Execute(writer, resumeData)
+}
+
+// fromFile loads a resume data file in any registered format, detected from its contents if necessary, in strict mode
+// if requested.  A file extending a base file is resolved (see "data.ResolveFile()").
+func fromFile(filename string, strict bool) (data.ResumeData, error) {
+	if strict {
+		return data.ResolveFileStrict(filename)
+	}
+	return data.ResolveFile(filename)
+}
+
+// validateForExport applies the validation mode from an export's options, returning an error only if the export
+// should be refused.
+func validateForExport(resumeData data.ResumeData, options ExportOptions) error {
+	if options.Validation == ValidationOff {
+		return nil
+	}
+	issues := data.Validate(resumeData)
+	if options.Validation == ValidationStrict && data.HasErrors(issues) {
+		return data.ValidationError{Issues: issues}
+	}
+	if options.Warnings == nil {
+		return nil
+	}
+	for _, issue := range issues {
+		if _, err := fmt.Fprintln(options.Warnings, issue); err != nil {
+			return err
+		}
+	}
+	return nil
 }
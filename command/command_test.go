@@ -1,12 +1,18 @@
 package command_test
 
 import (
+	"archive/zip"
+	"bytes"
+	"fmt"
 	"gitlab.com/steve-perkins/ResumeFodder/command"
 	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/fake"
 	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -54,6 +60,40 @@ func TestInitResumeXml(t *testing.T) {
 	}
 }
 
+func TestInitResumeYaml(t *testing.T) {
+	yaml, err := command.InitResumeYaml()
+	if err != nil {
+		t.Fatal(err)
+	}
+	inMemory := data.NewResumeData()
+	fromString, err := data.FromYamlString(yaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(inMemory, fromString) {
+		t.Fatal("Resume data after conversion doesn't match the original")
+	}
+}
+
+func TestInitResumeFile_Toml(t *testing.T) {
+	filename := filepath.Join(os.TempDir(), "testresume.toml")
+	testutils.DeleteFileIfExists(t, filename)
+	defer testutils.DeleteFileIfExists(t, filename)
+
+	err := command.InitResumeFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inMemory := data.NewResumeData()
+	fromFile, err := data.FromTomlFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(inMemory, fromFile) {
+		t.Fatal("Resume data after TOML conversion doesn't match the original")
+	}
+}
+
 func TestConvertResumeFile(t *testing.T) {
 	xmlFilename := filepath.Join(os.TempDir(), "testresume.xml")
 	testutils.DeleteFileIfExists(t, xmlFilename)
@@ -82,6 +122,230 @@ func TestConvertResumeFile(t *testing.T) {
 	}
 }
 
+func TestConvertResumeFile_Yaml(t *testing.T) {
+	jsonFilename := filepath.Join(os.TempDir(), "testresume.json")
+	testutils.DeleteFileIfExists(t, jsonFilename)
+	defer testutils.DeleteFileIfExists(t, jsonFilename)
+
+	yamlFilename := filepath.Join(os.TempDir(), "testresume.yml")
+	testutils.DeleteFileIfExists(t, yamlFilename)
+	defer testutils.DeleteFileIfExists(t, yamlFilename)
+
+	originalData := testutils.GenerateTestResumeData()
+	if err := data.ToJsonFile(originalData, jsonFilename); err != nil {
+		t.Fatal(err)
+	}
+	if err := command.ConvertResumeFile(jsonFilename, yamlFilename); err != nil {
+		t.Fatal(err)
+	}
+	fromFile, err := data.FromYamlFile(yamlFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(originalData, fromFile) {
+		t.Fatal("Resume data after JSON-to-YAML conversion doesn't match the original")
+	}
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	xmlFilename := filepath.Join(os.TempDir(), "testresume.xml")
+	testutils.DeleteFileIfExists(t, xmlFilename)
+	defer testutils.DeleteFileIfExists(t, xmlFilename)
+	if err := command.InitResumeFile(xmlFilename); err != nil {
+		t.Fatal(err)
+	}
+	txtFilename := filepath.Join(os.TempDir(), "testresume.txt")
+	testutils.DeleteFileIfExists(t, txtFilename)
+	defer testutils.DeleteFileIfExists(t, txtFilename)
+	if err := command.InitResumeFile(txtFilename); err == nil {
+		t.Fatal("Expected an error creating a file in an unsupported format")
+	}
+	// The contents of input files are inspected, but aren't recognized either
+	if err := ioutil.WriteFile(txtFilename, []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := map[string]error{
+		"ConvertResumeFile output": command.ConvertResumeFile(xmlFilename, txtFilename),
+		"ConvertResumeFile input":  command.ConvertResumeFile(txtFilename, xmlFilename),
+		// The input format is checked before the template is looked for
+		"ExportResumeFile": command.ExportResumeFile(txtFilename, xmlFilename, filepath.Join(os.TempDir(), "no-such-template.xml")),
+	}
+	for name, err := range errs {
+		if _, ok := err.(data.UnsupportedFormatError); !ok {
+			t.Fatalf("Expected an UnsupportedFormatError from %s, found: %v", name, err)
+		}
+	}
+	if contents, _ := ioutil.ReadFile(txtFilename); string(contents) != "<html></html>" {
+		t.Fatal("Expected no file to be written in an unsupported format")
+	}
+}
+
+func TestDetectedFormat(t *testing.T) {
+	// Resume data files without a recognized extension are read according to their contents
+	inputFilename := filepath.Join(os.TempDir(), "testresume.txt")
+	testutils.DeleteFileIfExists(t, inputFilename)
+	defer testutils.DeleteFileIfExists(t, inputFilename)
+	outputFilename := filepath.Join(os.TempDir(), "testresume.yaml")
+	testutils.DeleteFileIfExists(t, outputFilename)
+	defer testutils.DeleteFileIfExists(t, outputFilename)
+
+	originalData := testutils.GenerateTestResumeData()
+	xml, err := data.ToXmlString(originalData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(inputFilename, []byte(xml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := command.ConvertResumeFile(inputFilename, outputFilename); err != nil {
+		t.Fatal(err)
+	}
+	fromFile, err := data.FromYamlFile(outputFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(originalData, fromFile) {
+		t.Fatal("Resume data after conversion from a detected format doesn't match the original")
+	}
+
+	// An old file is migrated in place, and kept in the same format
+	if err := ioutil.WriteFile(inputFilename, []byte(`{"basics": {"name": "Peter Gibbons"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer testutils.DeleteFileIfExists(t, inputFilename+".bak")
+	if _, err := command.MigrateResumeFile(inputFilename); err != nil {
+		t.Fatal(err)
+	}
+	migrated, err := data.FromJsonFile(inputFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if migrated.Version != data.SCHEMA_VERSION || migrated.Basics.Name != "Peter Gibbons" {
+		t.Fatalf("Unexpected migrated data: %+v", migrated)
+	}
+}
+
+func TestMigrateResumeFile(t *testing.T) {
+	jsonFilename := filepath.Join(os.TempDir(), "testresume.json")
+	testutils.DeleteFileIfExists(t, jsonFilename)
+	defer testutils.DeleteFileIfExists(t, jsonFilename)
+	backupFilename := jsonFilename + ".bak"
+	testutils.DeleteFileIfExists(t, backupFilename)
+	defer testutils.DeleteFileIfExists(t, backupFilename)
+
+	// Write a resume data file that predates schema versioning
+	resumeData := testutils.GenerateTestResumeData()
+	resumeData.Version = 0
+	err := data.ToJsonFile(resumeData, jsonFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := command.MigrateResumeFile(jsonFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Migrated() {
+		t.Fatal("Expected the resume data file to be migrated")
+	}
+	fromBackup, err := data.FromJsonFile(backupFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resumeData, fromBackup) {
+		t.Fatal("Backup file doesn't match the original resume data")
+	}
+	fromFile, err := data.FromJsonFile(jsonFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resumeData.Version = data.SCHEMA_VERSION
+	if !reflect.DeepEqual(resumeData, fromFile) {
+		t.Fatal("Resume data after migration doesn't match the original")
+	}
+
+	// Migrating again finds nothing to do, and leaves both files unchanged
+	migratedBytes, err := ioutil.ReadFile(jsonFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backupBytes, err := ioutil.ReadFile(backupFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err = command.MigrateResumeFile(jsonFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Migrated() {
+		t.Fatal("Expected a migrated file to already be current")
+	}
+	if contents, _ := ioutil.ReadFile(jsonFilename); !bytes.Equal(contents, migratedBytes) {
+		t.Fatalf("Expected the file to be unchanged by a second migration:\n%s", contents)
+	}
+	if contents, _ := ioutil.ReadFile(backupFilename); !bytes.Equal(contents, backupBytes) {
+		t.Fatalf("Expected the backup to be unchanged by a second migration:\n%s", contents)
+	}
+}
+
+func TestMigrateResumeFile_Overlay(t *testing.T) {
+	baseFilename := filepath.Join(os.TempDir(), "testresume-base.json")
+	defer testutils.DeleteFileIfExists(t, baseFilename)
+	overlayFilename := filepath.Join(os.TempDir(), "testresume-overlay.json")
+	defer testutils.DeleteFileIfExists(t, overlayFilename)
+	defer testutils.DeleteFileIfExists(t, overlayFilename+".bak")
+
+	base := `{"version": 1, "basics": {"name": "Peter", "summary": "base summary"}}`
+	if err := ioutil.WriteFile(baseFilename, []byte(base), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// The overlay has no version of its own, but must not be rewritten with every field blank
+	overlay := `{"extends": "testresume-base.json", "basics": {"label": "Waiter"}}`
+	if err := ioutil.WriteFile(overlayFilename, []byte(overlay), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := command.MigrateResumeFile(overlayFilename); err == nil {
+		t.Fatal("Expected an error migrating an overlay")
+	}
+	if contents, _ := ioutil.ReadFile(overlayFilename); string(contents) != overlay {
+		t.Fatalf("Expected the overlay to be left untouched:\n%s", contents)
+	}
+	resolved, err := data.ResolveFile(overlayFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.Basics.Name != "Peter" || resolved.Basics.Summary != "base summary" || resolved.Basics.Label != "Waiter" {
+		t.Fatalf("Unexpected resolved data: %+v", resolved.Basics)
+	}
+}
+
+func TestMigrateResumeFile_ForeignFormat(t *testing.T) {
+	europassFilename := filepath.Join(os.TempDir(), "testresume.europass.xml")
+	testutils.DeleteFileIfExists(t, europassFilename)
+	defer testutils.DeleteFileIfExists(t, europassFilename)
+	backupFilename := europassFilename + ".bak"
+	testutils.DeleteFileIfExists(t, backupFilename)
+	defer testutils.DeleteFileIfExists(t, backupFilename)
+
+	if err := data.ToFile(testutils.GenerateTestResumeData(), europassFilename); err != nil {
+		t.Fatal(err)
+	}
+	original, err := ioutil.ReadFile(europassFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := command.MigrateResumeFile(europassFilename); err == nil {
+		t.Fatal("Expected an error migrating a Europass file")
+	}
+	if contents, _ := ioutil.ReadFile(europassFilename); !bytes.Equal(contents, original) {
+		t.Fatal("Expected the Europass file to be left untouched")
+	}
+	if _, err := os.Stat(backupFilename); !os.IsNotExist(err) {
+		t.Fatal("Expected no backup file to be written")
+	}
+}
+
 // See also "TestExportResume_TemplateDefaultPath()", in the base "ResumeFodder" project's "main_test.go" test file.
 func TestExportResumeFile_TemplateRelativePath(t *testing.T) {
 	xmlFilename := filepath.Join(os.TempDir(), "testresume.xml")
@@ -101,3 +365,429 @@ func TestExportResumeFile_TemplateRelativePath(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestExportResume_ValidationStrict(t *testing.T) {
+	resumeData := testutils.GenerateTestResumeData()
+	resumeData.Work[0].StartDate = "sometime in 1998"
+
+	_, err := command.ExportResumeWithOptions(resumeData, "{{.Basics.Name}}", command.ExportOptions{Validation: command.ValidationStrict})
+	if _, ok := err.(data.ValidationError); !ok {
+		t.Fatalf("Expected a validation error, found: %v", err)
+	}
+
+	var warnings bytes.Buffer
+	buffer, err := command.ExportResumeWithOptions(resumeData, "{{.Basics.Name}}", command.ExportOptions{Validation: command.ValidationWarn, Warnings: &warnings})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buffer.String() != "Peter Gibbons" {
+		t.Fatalf("Unexpected export output: %s", buffer.String())
+	}
+	if !strings.Contains(warnings.String(), "/work/0/startDate") {
+		t.Fatalf("Expected a warning for the invalid start date, found: %s", warnings.String())
+	}
+
+	// Without a writer for them, the warnings are discarded
+	buffer, err = command.ExportResumeWithOptions(resumeData, "{{.Basics.Name}}", command.ExportOptions{Validation: command.ValidationWarn})
+	if err != nil || buffer.String() != "Peter Gibbons" {
+		t.Fatalf("Unexpected export result: %v", err)
+	}
+}
+
+func TestExportResume_DateFunctions(t *testing.T) {
+	resumeData := testutils.GenerateTestResumeData()
+	resumeData.Work[0].StartDate = "1998"
+	resumeData.Work[0].EndDate = data.Present
+	resumeData.AdditionalWork[0].StartDate = "1993-08"
+
+	template := `{{range .Work}}{{MYY .StartDate}} - {{MMMMYYYY .EndDate}}{{end}}|{{range .AdditionalWork}}{{MYYYY .StartDate}}{{end}}|{{YYYY "2014-06-01"}}`
+	buffer, err := command.ExportResume(resumeData, template)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "1998 - Present|8/1993|2014"; buffer.String() != expected {
+		t.Fatalf("Expected \"%s\", found \"%s\"", expected, buffer.String())
+	}
+}
+
+func TestExportResume_Variant(t *testing.T) {
+	resumeData := testutils.GenerateTestResumeData()
+	template := `{{range .Work}}{{range .Highlights}}[{{.}}]{{end}}{{end}}{{range .Skills}}[{{.Name}}]{{end}}`
+	options := command.ExportOptions{Variant: data.ParseSelector("management")}
+	buffer, err := command.ExportResumeWithOptions(resumeData, template, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "[As many as four people working right underneath me.][Communication]"; buffer.String() != expected {
+		t.Fatalf("Expected \"%s\", found \"%s\"", expected, buffer.String())
+	}
+}
+
+func TestExportResumeToWriter(t *testing.T) {
+	// Pipe resume data in and the rendered resume out, without touching the filesystem
+	json, err := data.ToJsonString(testutils.GenerateTestResumeData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resumeData, err := data.FromJsonReader(strings.NewReader(json))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output strings.Builder
+	err = command.ExportResumeToWriter(resumeData, `{{.Basics.Name}} <{{.Basics.Email}}>`, &output, command.ExportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "Peter Gibbons <peter.gibbons@initech.com>"; output.String() != expected {
+		t.Fatalf("Expected \"%s\", found \"%s\"", expected, output.String())
+	}
+}
+
+func TestExportResume_Groups(t *testing.T) {
+	resumeData := testutils.GenerateTestResumeData()
+	template := `{{range .AllWorkGroups}}[{{.Name}}:{{range .Work}}{{.Company}}{{end}}]{{end}}`
+	buffer, err := command.ExportResume(resumeData, template)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "[Professional Experience:Initech][Academic Work Experience:Flingers][Consulting:The Bobs]"; buffer.String() != expected {
+		t.Fatalf("Expected \"%s\", found \"%s\"", expected, buffer.String())
+	}
+}
+
+func TestExportResume_Roles(t *testing.T) {
+	resumeData := testutils.GenerateTestResumeData()
+	template := `{{range .Work}}{{.Company}} ({{YYYY .TenureStart}}-{{YYYY .TenureEnd}}){{range .AllRoles}}[{{.Title}}]{{end}}{{end}}`
+	buffer, err := command.ExportResume(resumeData, template)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "Initech (1998-)[Senior Software Developer][Software Developer]"; buffer.String() != expected {
+		t.Fatalf("Expected \"%s\", found \"%s\"", expected, buffer.String())
+	}
+}
+
+func TestExportResume_Extra(t *testing.T) {
+	resumeData, err := data.FromJsonString(`{"basics": {"name": "Peter Gibbons", "x-nickname": "Pete"}, "meta": {"version": "v1.0.0"}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := `{{.Basics.Extra.Get "x-nickname"}} {{.Extra.Get "meta.version"}}{{with .Extra.Get "meta.missing"}}!{{end}}`
+	buffer, err := command.ExportResume(resumeData, template)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "Pete v1.0.0"; buffer.String() != expected {
+		t.Fatalf("Expected \"%s\", found \"%s\"", expected, buffer.String())
+	}
+}
+
+func TestConvertResumeFile_Strict(t *testing.T) {
+	jsonFilename := filepath.Join(os.TempDir(), "testresume.json")
+	testutils.DeleteFileIfExists(t, jsonFilename)
+	defer testutils.DeleteFileIfExists(t, jsonFilename)
+	xmlFilename := filepath.Join(os.TempDir(), "testresume.xml")
+	testutils.DeleteFileIfExists(t, xmlFilename)
+	defer testutils.DeleteFileIfExists(t, xmlFilename)
+
+	err := ioutil.WriteFile(jsonFilename, []byte("{\n  \"basics\": {\"nmae\": \"Peter Gibbons\"},\n  \"work\": [{\"hightlights\": []}]\n}"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = command.ConvertResumeFileWithOptions(jsonFilename, xmlFilename, command.ConvertOptions{Strict: true})
+	decodeErr, ok := err.(data.DecodeError)
+	if !ok {
+		t.Fatalf("Expected a DecodeError, found: %v", err)
+	}
+	if len(decodeErr.Diagnostics) != 2 || decodeErr.Diagnostics[1].File != jsonFilename || decodeErr.Diagnostics[1].Line != 3 {
+		t.Fatalf("Unexpected diagnostics: %v", decodeErr)
+	}
+
+	// Without strict mode, the unrecognized fields are carried through as extra fields
+	err = command.ConvertResumeFile(jsonFilename, xmlFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImportLinkedInFile(t *testing.T) {
+	zipFilename := filepath.Join(os.TempDir(), "testlinkedin.zip")
+	testutils.DeleteFileIfExists(t, zipFilename)
+	defer testutils.DeleteFileIfExists(t, zipFilename)
+	jsonFilename := filepath.Join(os.TempDir(), "testresume.json")
+	testutils.DeleteFileIfExists(t, jsonFilename)
+	defer testutils.DeleteFileIfExists(t, jsonFilename)
+
+	zipFile, err := os.Create(zipFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive := zip.NewWriter(zipFile)
+	positions, err := archive.Create("Positions.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = positions.Write([]byte("Company Name,Title,Description,Location,Started On,Finished On,Employment Type\n" +
+		"Initech,Software Developer,,,Feb 1998,,Full-time\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	zipFile.Close()
+
+	report, err := command.ImportLinkedInFile(zipFilename, jsonFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Unmapped) != 1 || report.Unmapped[0].Field != "Employment Type" {
+		t.Fatalf("Unexpected unmapped columns: %v", report.Unmapped)
+	}
+	fromFile, err := data.FromJsonFile(jsonFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fromFile.Work) != 1 || fromFile.Work[0].Company != "Initech" || fromFile.Work[0].StartDate != "1998-02" {
+		t.Fatalf("Unexpected imported work: %+v", fromFile.Work)
+	}
+}
+
+func TestConvertResumeFile_Europass(t *testing.T) {
+	jsonFilename := filepath.Join(os.TempDir(), "testresume.json")
+	testutils.DeleteFileIfExists(t, jsonFilename)
+	defer testutils.DeleteFileIfExists(t, jsonFilename)
+	europassFilename := filepath.Join(os.TempDir(), "testresume.europass.xml")
+	testutils.DeleteFileIfExists(t, europassFilename)
+	defer testutils.DeleteFileIfExists(t, europassFilename)
+
+	if err := data.ToJsonFile(testutils.GenerateTestResumeData(), jsonFilename); err != nil {
+		t.Fatal(err)
+	}
+	report, err := command.ConvertResumeFileWithOptions(jsonFilename, europassFilename, command.ConvertOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	warnings := strings.Join(report.Warnings(), "\n")
+	if !strings.Contains(warnings+"\n", "Not exported: basics: summary\n") {
+		t.Fatalf("Unexpected warnings:\n%s", warnings)
+	}
+	contents, err := ioutil.ReadFile(europassFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(contents), "<SkillsPassport") {
+		t.Fatalf("Expected a Europass document:\n%s", contents)
+	}
+
+	// Converting back to resume data reports nothing, since Europass has no fields beyond those that were written
+	report, err = command.ConvertResumeFileWithOptions(europassFilename, jsonFilename, command.ConvertOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Warnings()) > 0 {
+		t.Fatalf("Unexpected warnings: %v", report.Warnings())
+	}
+	fromFile, err := data.FromJsonFile(jsonFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromFile.Basics.Name != "Peter Gibbons" || len(fromFile.Work) != 4 {
+		t.Fatalf("Unexpected resume data after Europass conversion: %+v", fromFile)
+	}
+}
+
+func TestConvertResumeFile_StrictReport(t *testing.T) {
+	hrOpenFilename := filepath.Join(os.TempDir(), "testresume.hropen.xml")
+	testutils.DeleteFileIfExists(t, hrOpenFilename)
+	defer testutils.DeleteFileIfExists(t, hrOpenFilename)
+	jsonFilename := filepath.Join(os.TempDir(), "testresume.json")
+	testutils.DeleteFileIfExists(t, jsonFilename)
+	defer testutils.DeleteFileIfExists(t, jsonFilename)
+
+	hrOpen := `<Candidate xmlns="http://www.hr-xml.org/3">
+  <CandidatePerson>
+    <PersonName>
+      <FormattedName>Michael Bolton</FormattedName>
+      <PreferredName>Mike</PreferredName>
+    </PersonName>
+  </CandidatePerson>
+</Candidate>`
+	if err := ioutil.WriteFile(hrOpenFilename, []byte(hrOpen), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Foreign formats don't support strict decoding, but still report the fields left behind
+	report, err := command.ConvertResumeFileWithOptions(hrOpenFilename, jsonFilename, command.ConvertOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	warnings := strings.Join(report.Warnings(), "\n")
+	if !strings.Contains(warnings, "Not imported: HR Open: Candidate.CandidatePerson.PersonName.PreferredName") {
+		t.Fatalf("Unexpected warnings:\n%s", warnings)
+	}
+}
+
+func TestDiffResumeFiles(t *testing.T) {
+	xmlFilename := filepath.Join(os.TempDir(), "testresume.xml")
+	testutils.DeleteFileIfExists(t, xmlFilename)
+	defer testutils.DeleteFileIfExists(t, xmlFilename)
+	jsonFilename := filepath.Join(os.TempDir(), "testresume.json")
+	testutils.DeleteFileIfExists(t, jsonFilename)
+	defer testutils.DeleteFileIfExists(t, jsonFilename)
+
+	resumeData := testutils.GenerateTestResumeData()
+	if err := data.ToXmlFile(resumeData, xmlFilename); err != nil {
+		t.Fatal(err)
+	}
+	resumeData.Basics.Label = "Consultant"
+	if err := data.ToJsonFile(resumeData, jsonFilename); err != nil {
+		t.Fatal(err)
+	}
+
+	var output bytes.Buffer
+	report, err := command.DiffResumeFiles(xmlFilename, jsonFilename, command.DiffText, &output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Changes) != 1 || !strings.HasPrefix(output.String(), "~ basics.label: ") {
+		t.Fatalf("Unexpected report:\n%s", output.String())
+	}
+
+	output.Reset()
+	if _, err := command.DiffResumeFiles(xmlFilename, jsonFilename, command.DiffJson, &output); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output.String(), "\"path\": \"basics.label\"") || !strings.Contains(output.String(), "\"new\": \"Consultant\"") {
+		t.Fatalf("Unexpected JSON report:\n%s", output.String())
+	}
+}
+
+func TestResolveResumeFile(t *testing.T) {
+	baseFilename := filepath.Join(os.TempDir(), "testresume.xml")
+	testutils.DeleteFileIfExists(t, baseFilename)
+	defer testutils.DeleteFileIfExists(t, baseFilename)
+	overlayFilename := filepath.Join(os.TempDir(), "testresume-overlay.yaml")
+	testutils.DeleteFileIfExists(t, overlayFilename)
+	defer testutils.DeleteFileIfExists(t, overlayFilename)
+
+	if err := data.ToXmlFile(testutils.GenerateTestResumeData(), baseFilename); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(overlayFilename, []byte("extends: testresume.xml\nbasics:\n  label: Waiter\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var output bytes.Buffer
+	if err := command.ResolveResumeFile(overlayFilename, "json", &output); err != nil {
+		t.Fatal(err)
+	}
+	resolved, err := data.FromJsonString(output.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.Basics.Label != "Waiter" || resolved.Basics.Name != "Peter Gibbons" {
+		t.Fatalf("Unexpected resolved data:\n%s", output.String())
+	}
+}
+
+func TestGenerateResumeFiles(t *testing.T) {
+	pattern := filepath.Join(os.TempDir(), "testresume-generated-%02d.json")
+	options := fake.NewGeneratorOptions(42)
+	options.Work = fake.Range{Min: 12, Max: 12}
+	options.NameLength = fake.Range{Min: 40, Max: 40}
+	filenames, err := command.GenerateResumeFiles(pattern, 3, options)
+	for _, filename := range filenames {
+		defer testutils.DeleteFileIfExists(t, filename)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filenames) != 3 || filenames[0] != fmt.Sprintf(pattern, 1) {
+		t.Fatalf("Unexpected filenames: %v", filenames)
+	}
+
+	// The same seed always writes the same set of files, while a nearby seed writes an unrelated set
+	repeatPattern := filepath.Join(os.TempDir(), "testresume-repeated-%02d.json")
+	repeated, err := command.GenerateResumeFiles(repeatPattern, 3, options)
+	for _, filename := range repeated {
+		defer testutils.DeleteFileIfExists(t, filename)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherOptions := options
+	otherOptions.Seed = options.Seed + 1
+	otherPattern := filepath.Join(os.TempDir(), "testresume-other-%02d.json")
+	others, err := command.GenerateResumeFiles(otherPattern, 3, otherOptions)
+	for _, filename := range others {
+		defer testutils.DeleteFileIfExists(t, filename)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for index, filename := range filenames {
+		fromFile, err := data.FromFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		repeatedData, err := data.FromFile(repeated[index])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if report := data.Diff(repeatedData, fromFile); !report.Empty() {
+			t.Fatalf("Generated data differs for the same seed:\n%s", report)
+		}
+		for _, other := range others {
+			otherData, err := data.FromFile(other)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if data.Diff(otherData, fromFile).Empty() {
+				t.Fatalf("The files for seeds %d and %d should be unrelated", options.Seed, otherOptions.Seed)
+			}
+		}
+		if len(fromFile.Work) != 12 || len([]rune(fromFile.Work[0].Company)) != 40 {
+			t.Fatalf("Unexpected work history: %+v", fromFile.Work)
+		}
+		if issues := data.Validate(fromFile); data.HasErrors(issues) {
+			t.Fatalf("Generated data should be valid: %v", issues)
+		}
+		templateFilename := filepath.Join("..", "templates", "standard.xml")
+		outputFilename := filepath.Join(os.TempDir(), "testresume-generated.doc")
+		defer testutils.DeleteFileIfExists(t, outputFilename)
+		if err := command.ExportResumeFile(filename, outputFilename, templateFilename); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := command.GenerateResumeFiles(filepath.Join(os.TempDir(), "testresume.json"), 2, options); err == nil {
+		t.Fatal("Expected an error for a pattern without a verb")
+	}
+}
+
+func TestGenerateResumeFiles_InvalidPattern(t *testing.T) {
+	options := fake.NewGeneratorOptions(42)
+	for _, pattern := range []string{"out/%s.json", "out/100%.json", "out/%d-%d.json", "out/%5d.json", "out/%v.json"} {
+		filenames, err := command.GenerateResumeFiles(filepath.Join(os.TempDir(), pattern), 2, options)
+		if err == nil {
+			t.Fatalf("Expected an error for the pattern \"%s\"", pattern)
+		}
+		if len(filenames) > 0 {
+			t.Fatalf("No files should be written for the pattern \"%s\", found %v", pattern, filenames)
+		}
+	}
+}
+
+func TestGenerateResumeFiles_LiteralPercent(t *testing.T) {
+	pattern := filepath.Join(os.TempDir(), "testresume-100%%-%d.json")
+	filenames, err := command.GenerateResumeFiles(pattern, 1, fake.NewGeneratorOptions(42))
+	for _, filename := range filenames {
+		defer testutils.DeleteFileIfExists(t, filename)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := filepath.Join(os.TempDir(), "testresume-100%-1.json"); len(filenames) != 1 || filenames[0] != expected {
+		t.Fatalf("Expected [%s], found %v", expected, filenames)
+	}
+}
@@ -0,0 +1,136 @@
+package command
+
+import (
+	"fmt"
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// OutputFormat writes resume data in a form meant for publishing rather than editing (e.g. JSON-LD for a personal
+// web site), as an alternative to applying a template.  The built-in formats are registered automatically, and
+// other packages may add their own with "RegisterOutputFormat()".
+type OutputFormat interface {
+	// Name is a short, unique identifier for the format (e.g. "jsonld").
+	Name() string
+	// Extensions lists the filename extensions used by the format, including the leading dot.  The first one is
+	// the preferred extension.
+	Extensions() []string
+	// MimeType is the media type of the format, for use in HTTP downloads.
+	MimeType() string
+	// Write renders resume data to a stream.
+	Write(resumeData data.ResumeData, writer io.Writer) error
+}
+
+// UnsupportedOutputFormatError is returned when an output format is requested by a name or filename extension that
+// doesn't match any registered format.
+type UnsupportedOutputFormatError struct {
+	Name string
+}
+
+func (err UnsupportedOutputFormatError) Error() string {
+	var names []string
+	for _, format := range OutputFormats() {
+		names = append(names, fmt.Sprintf("\"%s\"", format.Name()))
+	}
+	return fmt.Sprintf("Unsupported output format for %s.  Supported formats are: %s.", err.Name, strings.Join(names, ", "))
+}
+
+var outputFormats = map[string]OutputFormat{}
+
+func init() {
+	RegisterOutputFormat(jsonLdFormat{})
+	RegisterOutputFormat(jsonLdHtmlFormat{})
+	RegisterOutputFormat(vCardFormat{})
+	RegisterOutputFormat(hCardFormat{})
+}
+
+// RegisterOutputFormat adds an output format to the registry.  It panics if a format is already registered with the
+// same name, since that is a programming error.
+func RegisterOutputFormat(format OutputFormat) {
+	if _, exists := outputFormats[format.Name()]; exists {
+		panic(fmt.Sprintf("Output format \"%s\" is already registered", format.Name()))
+	}
+	outputFormats[format.Name()] = format
+}
+
+// OutputFormats returns every registered output format, sorted by name.
+func OutputFormats() []OutputFormat {
+	var names []string
+	for name := range outputFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	formats := make([]OutputFormat, len(names))
+	for index, name := range names {
+		formats[index] = outputFormats[name]
+	}
+	return formats
+}
+
+// OutputFormatByName returns the registered output format with the given name.
+func OutputFormatByName(name string) (OutputFormat, bool) {
+	format, ok := outputFormats[name]
+	return format, ok
+}
+
+// OutputFormatForFilename returns the registered output format matching a filename's extension.  As with
+// "data.CodecForFilename()", the longest matching extension wins (e.g. ".jsonld.html" rather than ".html").
+func OutputFormatForFilename(filename string) (OutputFormat, error) {
+	lower := strings.ToLower(path.Base(filename))
+	var match OutputFormat
+	matchLength := 0
+	for _, format := range OutputFormats() {
+		for _, extension := range format.Extensions() {
+			if strings.HasSuffix(lower, extension) && len(extension) > matchLength {
+				match, matchLength = format, len(extension)
+			}
+		}
+	}
+	if match == nil {
+		return nil, UnsupportedOutputFormatError{Name: filename}
+	}
+	return match, nil
+}
+
+// ExportResumeFileAs writes a resume data file in an output format (e.g. "jsonld"), rather than applying a
+// template.  If the format name is blank, then the format is chosen by the output filename's extension.  The
+// options' "Variant", "Redaction", "Strict" and "Validation" settings apply just as in
+// "ExportResumeFileWithOptions()".
+func ExportResumeFileAs(inputFilename, outputFilename, formatName string, options ExportOptions) error {
+	var format OutputFormat
+	if formatName == "" {
+		var err error
+		if format, err = OutputFormatForFilename(outputFilename); err != nil {
+			return err
+		}
+	} else {
+		var ok bool
+		if format, ok = OutputFormatByName(formatName); !ok {
+			return UnsupportedOutputFormatError{Name: formatName}
+		}
+	}
+	resumeData, err := fromFile(inputFilename, options.Strict)
+	if err != nil {
+		return err
+	}
+	outfile, err := os.OpenFile(outputFilename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer outfile.Close()
+	return ExportResumeAs(resumeData, format, outfile, options)
+}
+
+// ExportResumeAs writes resume data in an output format to a Writer, after applying the options' "Variant",
+// "Validation" and "Redaction" settings.
+func ExportResumeAs(resumeData data.ResumeData, format OutputFormat, writer io.Writer, options ExportOptions) error {
+	resumeData = data.Filter(resumeData, options.Variant)
+	if err := validateForExport(resumeData, options); err != nil {
+		return err
+	}
+	return format.Write(data.Redact(resumeData, options.Redaction), writer)
+}
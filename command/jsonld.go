@@ -0,0 +1,288 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"io"
+	"strings"
+)
+
+// jsonLdPerson is a schema.org "Person", as embedded in a web page for search engines and other crawlers.  Only the
+// properties that ResumeFodder has data for are written.  See https://schema.org/Person.
+type jsonLdPerson struct {
+	Context       string               `json:"@context"`
+	Type          string               `json:"@type"`
+	Name          string               `json:"name,omitempty"`
+	JobTitle      string               `json:"jobTitle,omitempty"`
+	Image         string               `json:"image,omitempty"`
+	Email         string               `json:"email,omitempty"`
+	Telephone     string               `json:"telephone,omitempty"`
+	Url           string               `json:"url,omitempty"`
+	Description   string               `json:"description,omitempty"`
+	Address       *jsonLdAddress       `json:"address,omitempty"`
+	SameAs        []string             `json:"sameAs,omitempty"`
+	WorksFor      []jsonLdOrganization `json:"worksFor,omitempty"`
+	HasOccupation []jsonLdOccupation   `json:"hasOccupation,omitempty"`
+	AlumniOf      []jsonLdOrganization `json:"alumniOf,omitempty"`
+	HasCredential []jsonLdCredential   `json:"hasCredential,omitempty"`
+	KnowsAbout    []string             `json:"knowsAbout,omitempty"`
+	KnowsLanguage []string             `json:"knowsLanguage,omitempty"`
+	Award         []string             `json:"award,omitempty"`
+	// Reverse holds the works that refer back to the person (as "author" or "contributor"), since schema.org has no
+	// "publications" property on "Person" itself.
+	Reverse *jsonLdReverse `json:"@reverse,omitempty"`
+}
+
+type jsonLdAddress struct {
+	Type            string `json:"@type"`
+	StreetAddress   string `json:"streetAddress,omitempty"`
+	PostalCode      string `json:"postalCode,omitempty"`
+	AddressLocality string `json:"addressLocality,omitempty"`
+	AddressRegion   string `json:"addressRegion,omitempty"`
+	AddressCountry  string `json:"addressCountry,omitempty"`
+}
+
+type jsonLdOrganization struct {
+	Type string `json:"@type"`
+	Name string `json:"name,omitempty"`
+	Url  string `json:"url,omitempty"`
+}
+
+type jsonLdOccupation struct {
+	Type             string   `json:"@type"`
+	Name             string   `json:"name,omitempty"`
+	Description      string   `json:"description,omitempty"`
+	Responsibilities []string `json:"responsibilities,omitempty"`
+}
+
+type jsonLdCredential struct {
+	Type               string              `json:"@type"`
+	Name               string              `json:"name,omitempty"`
+	CredentialCategory string              `json:"credentialCategory,omitempty"`
+	RecognizedBy       *jsonLdOrganization `json:"recognizedBy,omitempty"`
+	DateCreated        string              `json:"dateCreated,omitempty"`
+	Url                string              `json:"url,omitempty"`
+}
+
+type jsonLdCreativeWork struct {
+	Type          string              `json:"@type"`
+	Name          string              `json:"name,omitempty"`
+	Publisher     *jsonLdOrganization `json:"publisher,omitempty"`
+	DatePublished string              `json:"datePublished,omitempty"`
+	Url           string              `json:"url,omitempty"`
+	Abstract      string              `json:"abstract,omitempty"`
+	Isbn          string              `json:"isbn,omitempty"`
+}
+
+type jsonLdReverse struct {
+	Author      []jsonLdCreativeWork `json:"author,omitempty"`
+	Contributor []jsonLdCreativeWork `json:"contributor,omitempty"`
+}
+
+// ToJsonLd writes resume data as a schema.org "Person" in JSON-LD format, suitable for publishing alongside a
+// personal web site or online resume.
+//
+// Employers from the current jobs (i.e. without an end date) become "worksFor", and every role from the full work
+// history becomes an "Occupation" in "hasOccupation".  Educational institutions become "alumniOf" (with any degree
+// listed in "hasCredential", along with certificates), skill names and keywords become "knowsAbout", and social
+// profile URLs become "sameAs".  Publications in the first publication group are listed as works by the person as
+// "author", and those in any other group (e.g. "Technical Reviewer") as "contributor".  Inline Markdown is reduced
+// to plain text, and fields such as phone numbers are written only if present... so use a variant or redaction
+// beforehand, for data that shouldn't be published.
+func ToJsonLd(resumeData data.ResumeData, writer io.Writer) error {
+	jsonBytes, err := json.MarshalIndent(toJsonLd(resumeData), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(append(jsonBytes, '\n'))
+	return err
+}
+
+// ToJsonLdHtml writes the same JSON-LD document as "ToJsonLd()", wrapped in a "<script type="application/ld+json">"
+// element that can be pasted into the head of an HTML page.
+func ToJsonLdHtml(resumeData data.ResumeData, writer io.Writer) error {
+	var buffer bytes.Buffer
+	buffer.WriteString("<script type=\"application/ld+json\">\n")
+	// "encoding/json" escapes "<", ">" and "&" within strings, so no value can close the script element early.
+	if err := ToJsonLd(resumeData, &buffer); err != nil {
+		return err
+	}
+	buffer.WriteString("</script>\n")
+	_, err := buffer.WriteTo(writer)
+	return err
+}
+
+func toJsonLd(resumeData data.ResumeData) jsonLdPerson {
+	basics := resumeData.Basics
+	person := jsonLdPerson{
+		Context:     "https://schema.org",
+		Type:        "Person",
+		Name:        basics.Name,
+		JobTitle:    basics.Label,
+		Image:       basics.Picture,
+		Email:       basics.Email,
+		Telephone:   basics.Phone,
+		Url:         basics.Website,
+		Description: markdownToPlainText(basics.Summary),
+	}
+	location := basics.Location
+	if location.Address != "" || location.PostalCode != "" || location.City != "" || location.Region != "" || location.CountryCode != "" {
+		person.Address = &jsonLdAddress{
+			Type:            "PostalAddress",
+			StreetAddress:   location.Address,
+			PostalCode:      location.PostalCode,
+			AddressLocality: location.City,
+			AddressRegion:   location.Region,
+			AddressCountry:  location.CountryCode,
+		}
+	}
+	for _, profile := range basics.Profiles {
+		if profile.Url != "" {
+			person.SameAs = append(person.SameAs, profile.Url)
+		}
+	}
+
+	for _, group := range resumeData.AllWorkGroups() {
+		for _, work := range group.Work {
+			// Entries without a company name (e.g. after redaction) would be nameless Organization nodes
+			if _, end := work.Tenure(); strings.TrimSpace(work.Company) != "" && (end.IsBlank() || end.IsPresent()) {
+				person.WorksFor = append(person.WorksFor, jsonLdOrganization{Type: "Organization", Name: work.Company, Url: work.Website})
+			}
+			for _, role := range work.AllRoles() {
+				occupation := jsonLdOccupation{
+					Type:        "Occupation",
+					Name:        role.Title,
+					Description: markdownToPlainText(role.Summary),
+				}
+				for _, highlight := range role.Highlights {
+					occupation.Responsibilities = append(occupation.Responsibilities, markdownToPlainText(highlight.Text))
+				}
+				person.HasOccupation = append(person.HasOccupation, occupation)
+			}
+		}
+	}
+
+	for _, education := range resumeData.Education {
+		var institution *jsonLdOrganization
+		if strings.TrimSpace(education.Institution) != "" {
+			institution = &jsonLdOrganization{Type: "EducationalOrganization", Name: education.Institution}
+			person.AlumniOf = append(person.AlumniOf, *institution)
+		}
+		if degree := strings.TrimSpace(education.StudyType + " " + education.Area); degree != "" {
+			person.HasCredential = append(person.HasCredential, jsonLdCredential{
+				Type:               "EducationalOccupationalCredential",
+				Name:               degree,
+				CredentialCategory: "degree",
+				RecognizedBy:       institution,
+				DateCreated:        jsonLdDate(education.EndDate),
+			})
+		}
+	}
+	for _, certificate := range resumeData.Certificates {
+		credential := jsonLdCredential{
+			Type:               "EducationalOccupationalCredential",
+			Name:               certificate.Name,
+			CredentialCategory: "certificate",
+			DateCreated:        jsonLdDate(certificate.Date),
+			Url:                certificate.Url,
+		}
+		if certificate.Issuer != "" {
+			credential.RecognizedBy = &jsonLdOrganization{Type: "Organization", Name: certificate.Issuer}
+		}
+		person.HasCredential = append(person.HasCredential, credential)
+	}
+
+	known := map[string]bool{}
+	for _, skill := range resumeData.Skills {
+		for _, topic := range append([]string{skill.Name}, skill.Keywords...) {
+			if topic != "" && !known[strings.ToLower(topic)] {
+				known[strings.ToLower(topic)] = true
+				person.KnowsAbout = append(person.KnowsAbout, topic)
+			}
+		}
+	}
+	for _, language := range resumeData.Languages {
+		if language.Language != "" {
+			person.KnowsLanguage = append(person.KnowsLanguage, language.Language)
+		}
+	}
+	for _, award := range resumeData.Awards {
+		if award.Title != "" {
+			person.Award = append(person.Award, award.Title)
+		}
+	}
+
+	reverse := jsonLdReverse{}
+	for index, group := range resumeData.AllPublicationGroups() {
+		for _, publication := range group.Publications {
+			work := jsonLdCreativeWork{
+				Type:          "CreativeWork",
+				Name:          publication.Name,
+				DatePublished: jsonLdDate(publication.ReleaseDate),
+				Url:           publication.Website,
+				Abstract:      markdownToPlainText(publication.Summary),
+			}
+			if publication.ISBN != "" {
+				work.Type, work.Isbn = "Book", publication.ISBN
+			}
+			if publication.Publisher != "" {
+				work.Publisher = &jsonLdOrganization{Type: "Organization", Name: publication.Publisher}
+			}
+			if index == 0 {
+				reverse.Author = append(reverse.Author, work)
+			} else {
+				reverse.Contributor = append(reverse.Contributor, work)
+			}
+		}
+	}
+	if len(reverse.Author) > 0 || len(reverse.Contributor) > 0 {
+		person.Reverse = &reverse
+	}
+	return person
+}
+
+// jsonLdDate returns a date in the ISO 8601 form that schema.org expects, or blank for "present" and for dates
+// that aren't recognized.
+func jsonLdDate(date data.Date) string {
+	if date.IsPresent() || !date.Valid() {
+		return ""
+	}
+	return string(date)
+}
+
+type jsonLdFormat struct{}
+
+func (jsonLdFormat) Name() string {
+	return "jsonld"
+}
+
+func (jsonLdFormat) Extensions() []string {
+	return []string{".jsonld"}
+}
+
+func (jsonLdFormat) MimeType() string {
+	return "application/ld+json"
+}
+
+func (jsonLdFormat) Write(resumeData data.ResumeData, writer io.Writer) error {
+	return ToJsonLd(resumeData, writer)
+}
+
+type jsonLdHtmlFormat struct{}
+
+func (jsonLdHtmlFormat) Name() string {
+	return "jsonld-html"
+}
+
+func (jsonLdHtmlFormat) Extensions() []string {
+	return []string{".jsonld.html", ".jsonld.htm"}
+}
+
+func (jsonLdHtmlFormat) MimeType() string {
+	return "text/html"
+}
+
+func (jsonLdHtmlFormat) Write(resumeData data.ResumeData, writer io.Writer) error {
+	return ToJsonLdHtml(resumeData, writer)
+}
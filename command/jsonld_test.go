@@ -0,0 +1,222 @@
+package command_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"gitlab.com/steve-perkins/ResumeFodder/command"
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestToJsonLd(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := command.ToJsonLd(testutils.GenerateTestResumeData(), &buffer); err != nil {
+		t.Fatal(err)
+	}
+	var person map[string]interface{}
+	if err := json.Unmarshal(buffer.Bytes(), &person); err != nil {
+		t.Fatalf("Invalid JSON: %s\n%s", err, buffer.String())
+	}
+	if person["@context"] != "https://schema.org" || person["@type"] != "Person" || person["name"] != "Peter Gibbons" {
+		t.Fatalf("Unexpected person:\n%s", buffer.String())
+	}
+
+	// Initech is the only employer without an end date
+	worksFor := person["worksFor"].([]interface{})
+	if len(worksFor) != 1 || worksFor[0].(map[string]interface{})["name"] != "Initech" {
+		t.Fatalf("Unexpected worksFor: %v", worksFor)
+	}
+	occupations := person["hasOccupation"].([]interface{})
+	if occupations[0].(map[string]interface{})["name"] != "Senior Software Developer" ||
+		occupations[1].(map[string]interface{})["name"] != "Software Developer" {
+		t.Fatalf("Unexpected occupations: %v", occupations)
+	}
+	alumniOf := person["alumniOf"].([]interface{})
+	if alumniOf[0].(map[string]interface{})["@type"] != "EducationalOrganization" {
+		t.Fatalf("Unexpected alumniOf: %v", alumniOf)
+	}
+	if !containsValue(person["knowsAbout"], "Programming") || !containsValue(person["knowsLanguage"], "Spanish") {
+		t.Fatalf("Unexpected knowsAbout or knowsLanguage:\n%s", buffer.String())
+	}
+
+	reverse := person["@reverse"].(map[string]interface{})
+	authored := reverse["author"].([]interface{})[0].(map[string]interface{})
+	if authored["@type"] != "Book" || authored["isbn"] != "1234567890X" {
+		t.Fatalf("Unexpected publication: %v", authored)
+	}
+	if _, ok := reverse["contributor"]; !ok {
+		t.Fatalf("Expected additional publications as contributor:\n%s", buffer.String())
+	}
+}
+
+func TestToJsonLd_BlankOrganizations(t *testing.T) {
+	resumeData := data.ResumeData{
+		Basics:    data.Basics{Name: "Peter Gibbons"},
+		Work:      []data.Work{{Company: "", Position: "Consultant"}, {Company: "Initech"}},
+		Education: []data.Education{{Institution: " ", Area: "Computer Science"}},
+	}
+	var buffer bytes.Buffer
+	if err := command.ToJsonLd(resumeData, &buffer); err != nil {
+		t.Fatal(err)
+	}
+	var person map[string]interface{}
+	if err := json.Unmarshal(buffer.Bytes(), &person); err != nil {
+		t.Fatalf("Invalid JSON: %s\n%s", err, buffer.String())
+	}
+	// Only named organizations are written, but the degree is still listed as a credential
+	worksFor, _ := person["worksFor"].([]interface{})
+	if len(worksFor) != 1 || worksFor[0].(map[string]interface{})["name"] != "Initech" {
+		t.Fatalf("Unexpected worksFor: %v", worksFor)
+	}
+	if _, ok := person["alumniOf"]; ok {
+		t.Fatalf("Expected no alumniOf for a blank institution:\n%s", buffer.String())
+	}
+	credentials, _ := person["hasCredential"].([]interface{})
+	if len(credentials) != 1 {
+		t.Fatalf("Unexpected credentials:\n%s", buffer.String())
+	}
+	if _, ok := credentials[0].(map[string]interface{})["recognizedBy"]; ok {
+		t.Fatalf("Expected no recognizedBy for a blank institution:\n%s", buffer.String())
+	}
+}
+
+func TestToJsonLdHtml(t *testing.T) {
+	resumeData := data.ResumeData{Basics: data.Basics{Name: "</script><b>Peter</b>"}}
+	var buffer bytes.Buffer
+	if err := command.ToJsonLdHtml(resumeData, &buffer); err != nil {
+		t.Fatal(err)
+	}
+	html := buffer.String()
+	if !strings.HasPrefix(html, "<script type=\"application/ld+json\">\n{") || !strings.HasSuffix(html, "}\n</script>\n") {
+		t.Fatalf("Unexpected HTML snippet:\n%s", html)
+	}
+	if strings.Count(html, "</script>") != 1 {
+		t.Fatalf("Field values must not close the script element:\n%s", html)
+	}
+}
+
+func TestExportResumeFileAs(t *testing.T) {
+	jsonFilename := filepath.Join(os.TempDir(), "testresume.json")
+	testutils.DeleteFileIfExists(t, jsonFilename)
+	defer testutils.DeleteFileIfExists(t, jsonFilename)
+	htmlFilename := filepath.Join(os.TempDir(), "testresume.jsonld.html")
+	testutils.DeleteFileIfExists(t, htmlFilename)
+	defer testutils.DeleteFileIfExists(t, htmlFilename)
+
+	if err := data.ToJsonFile(testutils.GenerateTestResumeData(), jsonFilename); err != nil {
+		t.Fatal(err)
+	}
+	// The format is chosen by the output filename's longest matching extension
+	if err := command.ExportResumeFileAs(jsonFilename, htmlFilename, "", command.ExportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	contents, err := ioutil.ReadFile(htmlFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(contents), "<script type=\"application/ld+json\">") {
+		t.Fatalf("Expected an HTML snippet:\n%s", contents)
+	}
+
+	// ... or by name, regardless of the extension
+	if err := command.ExportResumeFileAs(jsonFilename, htmlFilename, "jsonld", command.ExportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if contents, err = ioutil.ReadFile(htmlFilename); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(contents), "{") {
+		t.Fatalf("Expected a JSON-LD document:\n%s", contents)
+	}
+
+	err = command.ExportResumeFileAs(jsonFilename, htmlFilename, "pdf", command.ExportOptions{})
+	if _, ok := err.(command.UnsupportedOutputFormatError); !ok {
+		t.Fatalf("Expected an UnsupportedOutputFormatError, found: %v", err)
+	}
+}
+
+func TestOutputFormats(t *testing.T) {
+	var names []string
+	for _, format := range command.OutputFormats() {
+		names = append(names, format.Name())
+	}
+	expected := []string{"hcard", "jsonld", "jsonld-html", "vcard"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Expected output formats %v, found %v", expected, names)
+	}
+}
+
+func containsValue(list interface{}, value string) bool {
+	items, _ := list.([]interface{})
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestExportResumeAs_Redaction(t *testing.T) {
+	redaction, err := data.ParseRedaction("public")
+	if err != nil {
+		t.Fatal(err)
+	}
+	format, _ := command.OutputFormatByName("jsonld")
+	var buffer bytes.Buffer
+	err = command.ExportResumeAs(testutils.GenerateTestResumeData(), format, &buffer, command.ExportOptions{Redaction: redaction})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buffer.String(), "555-555-5555") || strings.Contains(buffer.String(), "123 Main Street") {
+		t.Fatalf("Expected private details to be redacted:\n%s", buffer.String())
+	}
+}
+
+func TestExport_AnonymousRedaction(t *testing.T) {
+	redaction, err := data.ParseRedaction("anonymous")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resumeData := testutils.GenerateTestResumeData()
+	options := command.ExportOptions{Redaction: redaction}
+	// The employer's name must not survive in any format, whether as a field value or a mention in other text
+	hasEmployer := func(output string) bool {
+		return strings.Contains(strings.ToLower(output), "initech")
+	}
+
+	for _, format := range command.OutputFormats() {
+		var buffer bytes.Buffer
+		if err := command.ExportResumeAs(resumeData, format, &buffer, options); err != nil {
+			t.Fatal(err)
+		}
+		if hasEmployer(buffer.String()) {
+			t.Fatalf("Expected the employer to be redacted from the %s format:\n%s", format.Name(), buffer.String())
+		}
+	}
+	for _, codec := range data.Codecs() {
+		var buffer bytes.Buffer
+		if err := codec.Encode(data.Redact(resumeData, redaction), &buffer); err != nil {
+			t.Fatal(err)
+		}
+		if hasEmployer(buffer.String()) {
+			t.Fatalf("Expected the employer to be redacted from the %s format:\n%s", codec.Name(), buffer.String())
+		}
+	}
+	templateContent, err := ioutil.ReadFile(filepath.Join("..", "templates", "standard.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	buffer, err := command.ExportResumeWithOptions(resumeData, string(templateContent), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hasEmployer(buffer.String()) {
+		t.Fatalf("Expected the employer to be redacted from the Word template:\n%s", buffer.String())
+	}
+}
@@ -0,0 +1,267 @@
+package command
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+)
+
+// markdownSpan is a run of text sharing the same formatting, produced by parsing a field value as Markdown.
+type markdownSpan struct {
+	text   string
+	bold   bool
+	italic bool
+	code   bool
+	link   string
+	// lineBreak spans have no text, and mark a hard line break.
+	lineBreak bool
+}
+
+// parseMarkdown parses the small subset of inline Markdown that makes sense within resume text fields:  bold
+// ("**text**" or "__text__"), italic ("*text*" or "_text_"), code ("`text`"), links ("[text](url)") and line
+// breaks.  Anything else (including unclosed markup) is treated as literal text, and a backslash escapes the
+// character that follows it.
+func parseMarkdown(source string) []markdownSpan {
+	var spans []markdownSpan
+	parseMarkdownInline([]rune(source), markdownSpan{}, &spans)
+	return mergeMarkdownSpans(spans)
+}
+
+func parseMarkdownInline(source []rune, style markdownSpan, spans *[]markdownSpan) {
+	var text []rune
+	flush := func() {
+		if len(text) > 0 {
+			span := style
+			span.text = string(text)
+			*spans = append(*spans, span)
+			text = nil
+		}
+	}
+	for index := 0; index < len(source); index++ {
+		char := source[index]
+		switch {
+		case char == '\\' && index+1 < len(source):
+			index++
+			text = append(text, source[index])
+		case char == '\n':
+			flush()
+			// Markdown's trailing-double-space convention for hard breaks is also accepted, but the spaces are dropped.
+			*spans = trimTrailingSpaces(*spans)
+			*spans = append(*spans, markdownSpan{lineBreak: true})
+		case char == '`':
+			end := indexRune(source, '`', index+1)
+			if end < 0 {
+				text = append(text, char)
+				continue
+			}
+			flush()
+			span := style
+			span.code = true
+			span.text = string(source[index+1 : end])
+			*spans = append(*spans, span)
+			index = end
+		case (char == '*' || char == '_') && index+1 < len(source) && source[index+1] == char:
+			delimiter := []rune{char, char}
+			end := indexDelimiter(source, delimiter, index+2)
+			if end < 0 || !canOpenEmphasis(source, index, char) {
+				text = append(text, char, char)
+				index++
+				continue
+			}
+			flush()
+			nested := style
+			nested.bold = true
+			parseMarkdownInline(source[index+2:end], nested, spans)
+			index = end + 1
+		case char == '*' || char == '_':
+			end := indexDelimiter(source, []rune{char}, index+1)
+			if end < 0 || !canOpenEmphasis(source, index, char) {
+				text = append(text, char)
+				continue
+			}
+			flush()
+			nested := style
+			nested.italic = true
+			parseMarkdownInline(source[index+1:end], nested, spans)
+			index = end
+		case char == '[':
+			labelEnd := indexRune(source, ']', index+1)
+			if labelEnd < 0 || labelEnd+1 >= len(source) || source[labelEnd+1] != '(' {
+				text = append(text, char)
+				continue
+			}
+			urlEnd := indexRune(source, ')', labelEnd+2)
+			if urlEnd < 0 {
+				text = append(text, char)
+				continue
+			}
+			flush()
+			nested := style
+			nested.link = strings.TrimSpace(string(source[labelEnd+2 : urlEnd]))
+			parseMarkdownInline(source[index+1:labelEnd], nested, spans)
+			index = urlEnd
+		default:
+			text = append(text, char)
+		}
+	}
+	flush()
+}
+
+// canOpenEmphasis prevents underscores inside of words (e.g. "snake_case_names") from being treated as italics.
+func canOpenEmphasis(source []rune, index int, char rune) bool {
+	if char != '_' || index == 0 {
+		return true
+	}
+	previous := source[index-1]
+	return !(previous >= 'a' && previous <= 'z' || previous >= 'A' && previous <= 'Z' || previous >= '0' && previous <= '9')
+}
+
+func indexRune(source []rune, char rune, start int) int {
+	for index := start; index < len(source); index++ {
+		if source[index] == '\\' {
+			index++
+		} else if source[index] == char {
+			return index
+		}
+	}
+	return -1
+}
+
+// indexDelimiter finds the closing emphasis delimiter, skipping over escaped characters and code spans.  A single
+// "*" delimiter does not match either half of a "**" pair.
+func indexDelimiter(source []rune, delimiter []rune, start int) int {
+	for index := start; index < len(source); index++ {
+		switch {
+		case source[index] == '\\':
+			index++
+		case source[index] == '`':
+			if end := indexRune(source, '`', index+1); end >= 0 {
+				index = end
+			}
+		case index > start && matchesAt(source, delimiter, index):
+			if len(delimiter) == 1 && index+1 < len(source) && source[index+1] == delimiter[0] {
+				index++
+				continue
+			}
+			// With a closing run like "***", a "**" delimiter closes on the last two characters, leaving the first
+			// to close a nested italic.
+			for len(delimiter) == 2 && index+2 < len(source) && source[index+2] == delimiter[0] {
+				index++
+			}
+			return index
+		}
+	}
+	return -1
+}
+
+func matchesAt(source []rune, delimiter []rune, index int) bool {
+	if index+len(delimiter) > len(source) {
+		return false
+	}
+	for offset, char := range delimiter {
+		if source[index+offset] != char {
+			return false
+		}
+	}
+	return true
+}
+
+func trimTrailingSpaces(spans []markdownSpan) []markdownSpan {
+	if len(spans) > 0 && !spans[len(spans)-1].lineBreak && !spans[len(spans)-1].code {
+		last := &spans[len(spans)-1]
+		last.text = strings.TrimRight(last.text, " ")
+		if last.text == "" {
+			return spans[:len(spans)-1]
+		}
+	}
+	return spans
+}
+
+// mergeMarkdownSpans combines adjacent text spans with identical formatting, so that fewer Word runs are emitted.
+func mergeMarkdownSpans(spans []markdownSpan) []markdownSpan {
+	var merged []markdownSpan
+	for _, span := range spans {
+		if len(merged) > 0 {
+			last := &merged[len(merged)-1]
+			if !span.lineBreak && !last.lineBreak && span.bold == last.bold && span.italic == last.italic &&
+				span.code == last.code && span.link == last.link {
+				last.text += span.text
+				continue
+			}
+		}
+		merged = append(merged, span)
+	}
+	return merged
+}
+
+// markdownToWordML converts a field value containing Markdown into a sequence of Word 2003 XML runs ("w:r"), with
+// links wrapped in "w:hlink" elements.  The result belongs inside of a paragraph ("w:p") element, in place of the
+// run that a template would otherwise use for plain text.  All text and attribute values are XML-escaped.
+func markdownToWordML(source string) string {
+	var buffer bytes.Buffer
+	spans := parseMarkdown(source)
+	for index := 0; index < len(spans); index++ {
+		span := spans[index]
+		if span.link == "" {
+			writeWordMLRun(&buffer, span)
+			continue
+		}
+		// Group all consecutive spans for the same link (e.g. a link with partially bolded text) into one hyperlink
+		buffer.WriteString(`<w:hlink w:dest="`)
+		xml.EscapeText(&buffer, []byte(span.link))
+		buffer.WriteString(`">`)
+		for ; index < len(spans) && spans[index].link == span.link; index++ {
+			writeWordMLRun(&buffer, spans[index])
+		}
+		index--
+		buffer.WriteString(`</w:hlink>`)
+	}
+	return buffer.String()
+}
+
+func writeWordMLRun(buffer *bytes.Buffer, span markdownSpan) {
+	if span.lineBreak {
+		buffer.WriteString(`<w:r><w:br/></w:r>`)
+		return
+	}
+	buffer.WriteString(`<w:r>`)
+	if span.link != "" || span.code || span.bold || span.italic {
+		buffer.WriteString(`<w:rPr>`)
+		if span.link != "" {
+			buffer.WriteString(`<w:rStyle w:val="Hyperlink"/>`)
+		}
+		if span.code {
+			buffer.WriteString(`<w:rFonts w:ascii="Courier New" w:h-ansi="Courier New" w:cs="Courier New"/>`)
+		}
+		if span.bold {
+			buffer.WriteString(`<w:b/>`)
+		}
+		if span.italic {
+			buffer.WriteString(`<w:i/>`)
+		}
+		buffer.WriteString(`</w:rPr>`)
+	}
+	buffer.WriteString(`<w:t>`)
+	xml.EscapeText(buffer, []byte(span.text))
+	buffer.WriteString(`</w:t></w:r>`)
+}
+
+// markdownToPlainText strips the Markdown formatting from a field value, for templates producing something other
+// than Word documents.  Link URLs are kept in parentheses after the link text (unless the text is the URL itself),
+// and line breaks are kept as newlines.  No escaping is performed.
+func markdownToPlainText(source string) string {
+	var buffer bytes.Buffer
+	spans := parseMarkdown(source)
+	for index, span := range spans {
+		if span.lineBreak {
+			buffer.WriteString("\n")
+			continue
+		}
+		buffer.WriteString(span.text)
+		lastOfLink := index+1 == len(spans) || spans[index+1].link != span.link
+		if span.link != "" && lastOfLink && span.text != span.link {
+			buffer.WriteString(" (" + span.link + ")")
+		}
+	}
+	return buffer.String()
+}
@@ -0,0 +1,76 @@
+package command_test
+
+import (
+	"gitlab.com/steve-perkins/ResumeFodder/command"
+	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"testing"
+)
+
+// exportSummary renders the test resume's summary through a single template function.
+func exportSummary(t *testing.T, summary, function string) string {
+	resumeData := testutils.GenerateTestResumeData()
+	resumeData.Basics.Summary = summary
+	buffer, err := command.ExportResume(resumeData, "{{"+function+" .Basics.Summary}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buffer.String()
+}
+
+func TestMarkdownWordML(t *testing.T) {
+	tests := []struct {
+		markdown string
+		expected string
+	}{
+		{
+			"Plain & simple",
+			`<w:r><w:t>Plain &amp; simple</w:t></w:r>`,
+		},
+		{
+			"Led **Initech** rollout",
+			`<w:r><w:t>Led </w:t></w:r><w:r><w:rPr><w:b/></w:rPr><w:t>Initech</w:t></w:r><w:r><w:t> rollout</w:t></w:r>`,
+		},
+		{
+			"Wrote *Y2K and You* in `C++`",
+			`<w:r><w:t>Wrote </w:t></w:r><w:r><w:rPr><w:i/></w:rPr><w:t>Y2K and You</w:t></w:r><w:r><w:t> in </w:t></w:r>` +
+				`<w:r><w:rPr><w:rFonts w:ascii="Courier New" w:h-ansi="Courier New" w:cs="Courier New"/></w:rPr><w:t>C++</w:t></w:r>`,
+		},
+		{
+			"See [my **site**](http://example.com/?a=1&b=2)",
+			`<w:r><w:t>See </w:t></w:r><w:hlink w:dest="http://example.com/?a=1&amp;b=2">` +
+				`<w:r><w:rPr><w:rStyle w:val="Hyperlink"/></w:rPr><w:t>my </w:t></w:r>` +
+				`<w:r><w:rPr><w:rStyle w:val="Hyperlink"/><w:b/></w:rPr><w:t>site</w:t></w:r></w:hlink>`,
+		},
+		{
+			"Line one  \nLine two",
+			`<w:r><w:t>Line one</w:t></w:r><w:r><w:br/></w:r><w:r><w:t>Line two</w:t></w:r>`,
+		},
+		{
+			"snake_case_name, 2 * 3 and \\*literal\\* <tags>",
+			`<w:r><w:t>snake_case_name, 2 * 3 and *literal* &lt;tags&gt;</w:t></w:r>`,
+		},
+	}
+	for _, test := range tests {
+		if actual := exportSummary(t, test.markdown, "wordML"); actual != test.expected {
+			t.Fatalf("For \"%s\", expected:\n%s\nfound:\n%s", test.markdown, test.expected, actual)
+		}
+	}
+}
+
+func TestMarkdownPlainText(t *testing.T) {
+	tests := []struct {
+		markdown string
+		expected string
+	}{
+		{"Led **Initech** rollout", "Led Initech rollout"},
+		{"Wrote ***Y2K and You***", "Wrote Y2K and You"},
+		{"See [my site](http://example.com)", "See my site (http://example.com)"},
+		{"See [http://example.com](http://example.com)", "See http://example.com"},
+		{"Line one\nLine two", "Line one\nLine two"},
+	}
+	for _, test := range tests {
+		if actual := exportSummary(t, test.markdown, "plainText"); actual != test.expected {
+			t.Fatalf("For \"%s\", expected \"%s\", found \"%s\"", test.markdown, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package command
+
+import (
+	"bytes"
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"html"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// vCardLineLength is the maximum length of a vCard content line in octets, not counting the line break.  Longer
+// lines are folded onto continuation lines beginning with a single space (see RFC 6350 section 3.2).
+const vCardLineLength = 75
+
+// ToVCard writes the contact details from resume data as a vCard 4.0 (RFC 6350), which recruiters can import
+// into an address book.  The "Basics" fields supply the name, email, phone, website, photo and address, and each
+// social profile with a URL becomes an "X-SOCIALPROFILE" line.  The current job title and employer come from the
+// most recent work entry (see "currentPosition()"), and the basics label is written as the role.
+func ToVCard(resumeData data.ResumeData, writer io.Writer) error {
+	basics := resumeData.Basics
+	var buffer bytes.Buffer
+	line := func(name, value string) {
+		writeVCardLine(&buffer, name+":"+value)
+	}
+	line("BEGIN", "VCARD")
+	line("VERSION", "4.0")
+	line("FN", escapeVCardText(basics.Name))
+	given, family := splitName(basics.Name)
+	line("N", strings.Join([]string{escapeVCardText(family), escapeVCardText(given), "", "", ""}, ";"))
+	if work, role, ok := currentPosition(resumeData); ok {
+		if role.Title != "" {
+			line("TITLE", escapeVCardText(role.Title))
+		}
+		if work.Company != "" {
+			line("ORG", escapeVCardText(work.Company))
+		}
+	}
+	if basics.Label != "" {
+		line("ROLE", escapeVCardText(basics.Label))
+	}
+	if basics.Email != "" {
+		line("EMAIL", escapeVCardText(basics.Email))
+	}
+	if basics.Phone != "" {
+		line("TEL;VALUE=text", escapeVCardText(basics.Phone))
+	}
+	if basics.Website != "" {
+		line("URL", basics.Website)
+	}
+	if basics.Picture != "" {
+		line("PHOTO", basics.Picture)
+	}
+	location := basics.Location
+	if location.Address != "" || location.City != "" || location.Region != "" || location.PostalCode != "" || location.CountryCode != "" {
+		// The components are post office box, extended address, street, locality, region, postal code and country
+		components := []string{"", "", location.Address, location.City, location.Region, location.PostalCode, location.CountryCode}
+		for index, component := range components {
+			components[index] = escapeVCardText(component)
+		}
+		line("ADR", strings.Join(components, ";"))
+	}
+	for _, profile := range basics.Profiles {
+		if profile.Url == "" {
+			continue
+		}
+		name := "X-SOCIALPROFILE"
+		if profile.Network != "" {
+			name += ";TYPE=" + vCardParameter(strings.ToLower(profile.Network))
+		}
+		line(name, profile.Url)
+	}
+	line("END", "VCARD")
+	_, err := buffer.WriteTo(writer)
+	return err
+}
+
+// ToHCard writes the same contact details as "ToVCard()" as an HTML fragment marked up with the "h-resume" and
+// "h-card" microformats, for embedding in a web page.  The current position is written as an "h-event"
+// experience entry, and the basics summary as the résumé summary.
+func ToHCard(resumeData data.ResumeData, writer io.Writer) error {
+	basics := resumeData.Basics
+	var buffer bytes.Buffer
+	element := func(indent, tag, class, text string) {
+		if text != "" {
+			buffer.WriteString(indent + "<" + tag + " class=\"" + class + "\">" + html.EscapeString(text) + "</" + tag + ">\n")
+		}
+	}
+	link := func(indent, class, href, text string) {
+		if href != "" {
+			buffer.WriteString(indent + "<a class=\"" + class + "\" href=\"" + html.EscapeString(href) + "\">" + html.EscapeString(text) + "</a>\n")
+		}
+	}
+
+	buffer.WriteString("<div class=\"h-resume\">\n")
+	buffer.WriteString("  <div class=\"p-contact h-card\">\n")
+	if basics.Picture != "" {
+		buffer.WriteString("    <img class=\"u-photo\" src=\"" + html.EscapeString(basics.Picture) + "\" alt=\"" + html.EscapeString(basics.Name) + "\">\n")
+	}
+	element("    ", "span", "p-name", basics.Name)
+	element("    ", "span", "p-role", basics.Label)
+	work, role, hasPosition := currentPosition(resumeData)
+	if hasPosition {
+		element("    ", "span", "p-job-title", role.Title)
+		element("    ", "span", "p-org", work.Company)
+	}
+	link("    ", "u-email", mailtoUrl(basics.Email), basics.Email)
+	element("    ", "span", "p-tel", basics.Phone)
+	link("    ", "u-url", basics.Website, basics.Website)
+	location := basics.Location
+	if location.Address != "" || location.City != "" || location.Region != "" || location.PostalCode != "" || location.CountryCode != "" {
+		buffer.WriteString("    <div class=\"p-adr h-adr\">\n")
+		element("      ", "span", "p-street-address", location.Address)
+		element("      ", "span", "p-locality", location.City)
+		element("      ", "span", "p-region", location.Region)
+		element("      ", "span", "p-postal-code", location.PostalCode)
+		element("      ", "span", "p-country-name", location.CountryCode)
+		buffer.WriteString("    </div>\n")
+	}
+	for _, profile := range basics.Profiles {
+		if profile.Url == "" {
+			continue
+		}
+		text := profile.Network
+		if text == "" {
+			text = profile.Url
+		}
+		buffer.WriteString("    <a class=\"u-url\" rel=\"me\" href=\"" + html.EscapeString(profile.Url) + "\">" + html.EscapeString(text) + "</a>\n")
+	}
+	buffer.WriteString("  </div>\n")
+	element("  ", "p", "p-summary", markdownToPlainText(basics.Summary))
+	if hasPosition {
+		buffer.WriteString("  <div class=\"p-experience h-event\">\n")
+		element("    ", "span", "p-name", role.Title)
+		if work.Company != "" {
+			buffer.WriteString("    <span class=\"p-location h-card\"><span class=\"p-name p-org\">" + html.EscapeString(work.Company) + "</span></span>\n")
+		}
+		if role.StartDate.Precision() != data.PrecisionNone {
+			buffer.WriteString("    <time class=\"dt-start\" datetime=\"" + string(role.StartDate) + "\">" + string(role.StartDate) + "</time>\n")
+		}
+		if role.EndDate.Precision() != data.PrecisionNone {
+			buffer.WriteString("    <time class=\"dt-end\" datetime=\"" + string(role.EndDate) + "\">" + string(role.EndDate) + "</time>\n")
+		}
+		buffer.WriteString("  </div>\n")
+	}
+	buffer.WriteString("</div>\n")
+	_, err := buffer.WriteTo(writer)
+	return err
+}
+
+// currentPosition returns the most recent work entry across every work group, and its most recent role.  Entries
+// are ranked by the end of their tenure (with ongoing jobs ranked first), and then by the start.  The third
+// return value is false if there is no work history.
+func currentPosition(resumeData data.ResumeData) (data.Work, data.Role, bool) {
+	var latest data.Work
+	found := false
+	for _, group := range resumeData.AllWorkGroups() {
+		for _, work := range group.Work {
+			if !found || isMoreRecent(work, latest) {
+				latest, found = work, true
+			}
+		}
+	}
+	if !found {
+		return data.Work{}, data.Role{}, false
+	}
+	return latest, latest.AllRoles()[0], true
+}
+
+func isMoreRecent(work, other data.Work) bool {
+	start, end := work.Tenure()
+	otherStart, otherEnd := other.Tenure()
+	if end.IsBlank() {
+		end = data.Present
+	}
+	if otherEnd.IsBlank() {
+		otherEnd = data.Present
+	}
+	if comparison, ok := end.Compare(otherEnd); ok && comparison != 0 {
+		return comparison > 0
+	}
+	comparison, ok := start.Compare(otherStart)
+	return ok && comparison > 0
+}
+
+// splitName splits a full name into given and family names for the vCard "N" property, treating the last word as
+// the family name.  This is only a guess, but the "FN" property always carries the name exactly as written.
+func splitName(name string) (given, family string) {
+	words := strings.Fields(name)
+	if len(words) < 2 {
+		return "", name
+	}
+	return strings.Join(words[:len(words)-1], " "), words[len(words)-1]
+}
+
+func mailtoUrl(email string) string {
+	if email == "" {
+		return ""
+	}
+	return "mailto:" + email
+}
+
+// escapeVCardText escapes a text value per RFC 6350 section 3.4:  backslashes, commas and semicolons are
+// backslash-escaped, and line breaks are written as "\n".
+func escapeVCardText(text string) string {
+	replacer := strings.NewReplacer("\\", "\\\\", ",", "\\,", ";", "\\;", "\r\n", "\\n", "\n", "\\n", "\r", "\\n")
+	return replacer.Replace(text)
+}
+
+// vCardParameter returns a parameter value, quoted if it contains characters that are special within parameters.
+// Double quotes can't be escaped in parameter values, so they are dropped.
+func vCardParameter(value string) string {
+	value = strings.Replace(value, "\"", "", -1)
+	if strings.ContainsAny(value, ":;,") {
+		return "\"" + value + "\""
+	}
+	return value
+}
+
+// writeVCardLine writes a content line ending in CRLF, folding it every 75 octets without splitting a multi-byte
+// UTF-8 character.
+func writeVCardLine(buffer *bytes.Buffer, line string) {
+	limit := vCardLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buffer.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// Continuation lines begin with a space, which counts toward their length
+		limit = vCardLineLength - 1
+	}
+	buffer.WriteString(line + "\r\n")
+}
+
+type vCardFormat struct{}
+
+func (vCardFormat) Name() string {
+	return "vcard"
+}
+
+func (vCardFormat) Extensions() []string {
+	return []string{".vcf", ".vcard"}
+}
+
+func (vCardFormat) MimeType() string {
+	return "text/vcard"
+}
+
+func (vCardFormat) Write(resumeData data.ResumeData, writer io.Writer) error {
+	return ToVCard(resumeData, writer)
+}
+
+type hCardFormat struct{}
+
+func (hCardFormat) Name() string {
+	return "hcard"
+}
+
+func (hCardFormat) Extensions() []string {
+	return []string{".hcard.html", ".hcard.htm"}
+}
+
+func (hCardFormat) MimeType() string {
+	return "text/html"
+}
+
+func (hCardFormat) Write(resumeData data.ResumeData, writer io.Writer) error {
+	return ToHCard(resumeData, writer)
+}
@@ -0,0 +1,86 @@
+package command_test
+
+import (
+	"bytes"
+	"gitlab.com/steve-perkins/ResumeFodder/command"
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"strings"
+	"testing"
+)
+
+func TestToVCard(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := command.ToVCard(testutils.GenerateTestResumeData(), &buffer); err != nil {
+		t.Fatal(err)
+	}
+	vcard := buffer.String()
+	expected := []string{
+		"BEGIN:VCARD\r\nVERSION:4.0\r\n",
+		"FN:Peter Gibbons\r\n",
+		"N:Gibbons;Peter;;;\r\n",
+		"TITLE:Senior Software Developer\r\n",
+		"ORG:Initech\r\n",
+		"TEL;VALUE=text:555-555-5555\r\n",
+		"ADR:;;123 Main Street;",
+		"X-SOCIALPROFILE;TYPE=linkedin:http://linkedin.com/peter.gibbons\r\n",
+	}
+	for _, line := range expected {
+		if !strings.Contains(vcard, line) {
+			t.Fatalf("Expected \"%s\" in vCard:\n%s", line, vcard)
+		}
+	}
+	if !strings.HasSuffix(vcard, "END:VCARD\r\n") {
+		t.Fatalf("Unexpected vCard ending:\n%s", vcard)
+	}
+}
+
+func TestToVCard_EscapingAndFolding(t *testing.T) {
+	resumeData := data.ResumeData{Basics: data.Basics{
+		Name:  "Gibbons, Peter; Jr.",
+		Label: strings.Repeat("Ünïcödé ", 20) + "\nsecond line",
+	}}
+	var buffer bytes.Buffer
+	if err := command.ToVCard(resumeData, &buffer); err != nil {
+		t.Fatal(err)
+	}
+	vcard := buffer.String()
+	if !strings.Contains(vcard, "FN:Gibbons\\, Peter\\; Jr.\r\n") {
+		t.Fatalf("Expected escaped name:\n%s", vcard)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(vcard, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Fatalf("Line longer than 75 octets: %s", line)
+		}
+	}
+	unfolded := strings.Replace(vcard, "\r\n ", "", -1)
+	if !strings.Contains(unfolded, "ROLE:"+strings.Repeat("Ünïcödé ", 20)+"\\nsecond line\r\n") {
+		t.Fatalf("Folding should not change the content:\n%s", unfolded)
+	}
+}
+
+func TestToHCard(t *testing.T) {
+	resumeData := testutils.GenerateTestResumeData()
+	resumeData.Basics.Name = "Peter <Gibbons>"
+	var buffer bytes.Buffer
+	if err := command.ToHCard(resumeData, &buffer); err != nil {
+		t.Fatal(err)
+	}
+	fragment := buffer.String()
+	expected := []string{
+		"<div class=\"h-resume\">\n  <div class=\"p-contact h-card\">\n",
+		"<span class=\"p-name\">Peter &lt;Gibbons&gt;</span>",
+		"<span class=\"p-job-title\">Senior Software Developer</span>",
+		"<span class=\"p-org\">Initech</span>",
+		"<span class=\"p-street-address\">123 Main Street</span>",
+		"<a class=\"u-url\" rel=\"me\" href=\"http://linkedin.com/peter.gibbons\">LinkedIn</a>",
+		"<div class=\"p-experience h-event\">",
+		"<time class=\"dt-start\" datetime=\"1999-07-01\">",
+	}
+	for _, text := range expected {
+		if !strings.Contains(fragment, text) {
+			t.Fatalf("Expected \"%s\" in hCard:\n%s", text, fragment)
+		}
+	}
+}
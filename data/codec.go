@@ -0,0 +1,336 @@
+package data
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Codec reads and writes resume data in one file format (e.g. XML or JSON).  The built-in formats are registered
+// automatically, and other packages may add their own with "RegisterCodec()".
+type Codec interface {
+	// Name is a short, unique identifier for the format (e.g. "json").
+	Name() string
+	// Extensions lists the filename extensions used by the format, including the leading dot (e.g. ".yaml" and
+	// ".yml").  The first one is the preferred extension.
+	Extensions() []string
+	// MimeType is the media type of the format (e.g. "application/json"), for use in HTTP downloads.
+	MimeType() string
+	// Decode parses resume data from a stream (e.g. an open file, or an HTTP request body).
+	Decode(reader io.Reader) (ResumeData, error)
+	// Encode writes resume data to a stream (e.g. an open file, or an HTTP response).
+	Encode(data ResumeData, writer io.Writer) error
+}
+
+// StrictCodec is implemented by codecs that support strict decoding (see "FromXmlStringStrict()").  The filename is
+// used only to label any diagnostics, and may be blank.
+type StrictCodec interface {
+	Codec
+	DecodeStrict(reader io.Reader, filename string) (ResumeData, error)
+}
+
+// UnsupportedFormatError is returned when a filename's extension doesn't match any registered codec.
+type UnsupportedFormatError struct {
+	Filename string
+	// Detected is true if the file's contents were also inspected, and weren't recognized (see "DetectFormat()").
+	Detected bool
+}
+
+func (err UnsupportedFormatError) Error() string {
+	var extensions []string
+	for _, codec := range Codecs() {
+		for _, extension := range codec.Extensions() {
+			extensions = append(extensions, fmt.Sprintf("\"%s\"", extension))
+		}
+	}
+	sort.Strings(extensions)
+	supported := strings.Join(extensions, ", ")
+	if len(extensions) > 1 {
+		supported = strings.Join(extensions[:len(extensions)-1], ", ") + " or " + extensions[len(extensions)-1]
+	}
+	name := err.Filename
+	if name == "" {
+		name = "the resume data"
+	}
+	if err.Detected {
+		return fmt.Sprintf("Unsupported resume data format for %s.  The contents were not recognized, so the "+
+			"filename must end with %s.", name, supported)
+	}
+	return fmt.Sprintf("Unsupported resume data format for %s.  Filename must end with %s.", name, supported)
+}
+
+// codecs is the registry of file formats, keyed by name.  codecExtensions maps each lower-case extension to the name
+// of its codec.
+var (
+	codecs          = map[string]Codec{}
+	codecExtensions = map[string]string{}
+)
+
+func init() {
+	RegisterCodec(xmlCodec{})
+	RegisterCodec(jsonCodec{})
+	RegisterCodec(yamlCodec{})
+	RegisterCodec(tomlCodec{})
+}
+
+// RegisterCodec adds a file format to the registry.  It panics if a codec is already registered with the same name,
+// or for any of the same extensions, since either case is a programming error.
+func RegisterCodec(codec Codec) {
+	if _, exists := codecs[codec.Name()]; exists {
+		panic(fmt.Sprintf("data: codec \"%s\" is already registered", codec.Name()))
+	}
+	for _, extension := range codec.Extensions() {
+		if name, exists := codecExtensions[strings.ToLower(extension)]; exists {
+			panic(fmt.Sprintf("data: extension \"%s\" is already registered to codec \"%s\"", extension, name))
+		}
+	}
+	codecs[codec.Name()] = codec
+	for _, extension := range codec.Extensions() {
+		codecExtensions[strings.ToLower(extension)] = codec.Name()
+	}
+}
+
+// Codecs returns all registered file formats, ordered by name.
+func Codecs() []Codec {
+	list := make([]Codec, 0, len(codecs))
+	for _, codec := range codecs {
+		list = append(list, codec)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name() < list[j].Name()
+	})
+	return list
+}
+
+// CodecByName returns the registered file format with the given name, if any.
+func CodecByName(name string) (Codec, bool) {
+	codec, ok := codecs[name]
+	return codec, ok
+}
+
+// CodecForFilename returns the registered file format matching a filename's extension.  Extensions are compared
+// case-insensitively, and may have several parts (e.g. ".europass.xml"), in which case the longest matching extension
+// wins.  An "UnsupportedFormatError" is returned if there is no match.
+func CodecForFilename(filename string) (Codec, error) {
+	lower := strings.ToLower(path.Base(filename))
+	match := ""
+	for extension := range codecExtensions {
+		if strings.HasSuffix(lower, extension) && len(extension) > len(match) {
+			match = extension
+		}
+	}
+	if match != "" {
+		return codecs[codecExtensions[match]], nil
+	}
+	return nil, UnsupportedFormatError{Filename: filename}
+}
+
+// FromFile loads a ResumeData struct from a file in any registered format, chosen by the filename's extension or
+// else by the file's contents (see "DetectFileFormat()").
+func FromFile(filename string) (ResumeData, error) {
+	codec, err := DetectFileFormat(filename)
+	if err != nil {
+		return ResumeData{}, err
+	}
+	return readFile(filename, codec.Decode)
+}
+
+// FromFileStrict is a variant of "FromFile()" that uses strict decoding, for formats that support it (see
+// "StrictCodec").  Files in other formats are decoded normally.
+func FromFileStrict(filename string) (ResumeData, error) {
+	codec, err := DetectFileFormat(filename)
+	if err != nil {
+		return ResumeData{}, err
+	}
+	strictCodec, ok := codec.(StrictCodec)
+	if !ok {
+		return readFile(filename, codec.Decode)
+	}
+	return readFile(filename, func(reader io.Reader) (ResumeData, error) {
+		return strictCodec.DecodeStrict(reader, filename)
+	})
+}
+
+// ToFile writes a ResumeData struct to a file in any registered format, chosen by the filename's extension.
+func ToFile(data ResumeData, filename string) error {
+	codec, err := CodecForFilename(filename)
+	if err != nil {
+		return err
+	}
+	return writeFile(data, filename, codec.Encode)
+}
+
+// FromFileWithReport is a variant of "FromFile()", which also returns the data left behind when reading a file in a
+// foreign format (see "ReportingCodec").  The report is empty for other formats.
+func FromFileWithReport(filename string) (ResumeData, MappingReport, error) {
+	codec, err := DetectFileFormat(filename)
+	if err != nil {
+		return ResumeData{}, MappingReport{}, err
+	}
+	return readFileWithReport(filename, codec)
+}
+
+// FromFileStrictWithReport combines "FromFileStrict()" and "FromFileWithReport()".  Files in formats supporting
+// strict decoding are decoded strictly, and files in foreign formats return a report of the data left behind.
+func FromFileStrictWithReport(filename string) (ResumeData, MappingReport, error) {
+	codec, err := DetectFileFormat(filename)
+	if err != nil {
+		return ResumeData{}, MappingReport{}, err
+	}
+	if strictCodec, ok := codec.(StrictCodec); ok {
+		data, err := readFile(filename, func(reader io.Reader) (ResumeData, error) {
+			return strictCodec.DecodeStrict(reader, filename)
+		})
+		return data, MappingReport{}, err
+	}
+	return readFileWithReport(filename, codec)
+}
+
+// readFileWithReport reads a file with the given codec, returning a report of the data left behind if the codec
+// supports one (see "ReportingCodec").
+func readFileWithReport(filename string, codec Codec) (ResumeData, MappingReport, error) {
+	reportingCodec, ok := codec.(ReportingCodec)
+	if !ok {
+		data, err := readFile(filename, codec.Decode)
+		return data, MappingReport{}, err
+	}
+	report := MappingReport{}
+	data, err := readFile(filename, func(reader io.Reader) (ResumeData, error) {
+		var data ResumeData
+		var err error
+		data, report, err = reportingCodec.DecodeWithReport(reader)
+		return data, err
+	})
+	return data, report, err
+}
+
+// ToFileWithReport is a variant of "ToFile()", which also returns the data left behind when writing a file in a
+// foreign format (see "ReportingCodec").  The report is empty for other formats.
+func ToFileWithReport(data ResumeData, filename string) (MappingReport, error) {
+	codec, err := CodecForFilename(filename)
+	if err != nil {
+		return MappingReport{}, err
+	}
+	reportingCodec, ok := codec.(ReportingCodec)
+	if !ok {
+		return MappingReport{}, writeFile(data, filename, codec.Encode)
+	}
+	report := MappingReport{}
+	err = writeFile(data, filename, func(data ResumeData, writer io.Writer) error {
+		var err error
+		report, err = reportingCodec.EncodeWithReport(data, writer)
+		return err
+	})
+	return report, err
+}
+
+// xmlCodec is the built-in XML format.
+type xmlCodec struct{}
+
+func (xmlCodec) Name() string {
+	return "xml"
+}
+
+func (xmlCodec) Extensions() []string {
+	return []string{".xml"}
+}
+
+func (xmlCodec) MimeType() string {
+	return "application/xml"
+}
+
+func (xmlCodec) Decode(reader io.Reader) (ResumeData, error) {
+	return FromXmlReader(reader)
+}
+
+func (xmlCodec) Encode(data ResumeData, writer io.Writer) error {
+	return ToXmlWriter(data, writer)
+}
+
+func (xmlCodec) DecodeStrict(reader io.Reader, filename string) (ResumeData, error) {
+	bytes, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return ResumeData{}, err
+	}
+	return fromXmlStrict(bytes, filename)
+}
+
+// jsonCodec is the built-in JSON format.
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string {
+	return "json"
+}
+
+func (jsonCodec) Extensions() []string {
+	return []string{".json"}
+}
+
+func (jsonCodec) MimeType() string {
+	return "application/json"
+}
+
+func (jsonCodec) Decode(reader io.Reader) (ResumeData, error) {
+	return FromJsonReader(reader)
+}
+
+func (jsonCodec) Encode(data ResumeData, writer io.Writer) error {
+	return ToJsonWriter(data, writer)
+}
+
+func (jsonCodec) DecodeStrict(reader io.Reader, filename string) (ResumeData, error) {
+	bytes, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return ResumeData{}, err
+	}
+	return fromJsonStrict(bytes, filename)
+}
+
+// yamlCodec is the built-in YAML format.
+type yamlCodec struct{}
+
+func (yamlCodec) Name() string {
+	return "yaml"
+}
+
+func (yamlCodec) Extensions() []string {
+	return []string{".yaml", ".yml"}
+}
+
+func (yamlCodec) MimeType() string {
+	return "application/yaml"
+}
+
+func (yamlCodec) Decode(reader io.Reader) (ResumeData, error) {
+	return FromYamlReader(reader)
+}
+
+func (yamlCodec) Encode(data ResumeData, writer io.Writer) error {
+	return ToYamlWriter(data, writer)
+}
+
+// tomlCodec is the built-in TOML format.
+type tomlCodec struct{}
+
+func (tomlCodec) Name() string {
+	return "toml"
+}
+
+func (tomlCodec) Extensions() []string {
+	return []string{".toml"}
+}
+
+func (tomlCodec) MimeType() string {
+	return "application/toml"
+}
+
+func (tomlCodec) Decode(reader io.Reader) (ResumeData, error) {
+	return FromTomlReader(reader)
+}
+
+func (tomlCodec) Encode(data ResumeData, writer io.Writer) error {
+	return ToTomlWriter(data, writer)
+}
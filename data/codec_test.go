@@ -0,0 +1,175 @@
+package data_test
+
+import (
+	"bytes"
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// lineCodec is a trivial third-party format, holding only the candidate's name.
+type lineCodec struct{}
+
+func (lineCodec) Name() string {
+	return "line"
+}
+
+func (lineCodec) Extensions() []string {
+	return []string{".line"}
+}
+
+func (lineCodec) MimeType() string {
+	return "text/plain"
+}
+
+func (lineCodec) Decode(reader io.Reader) (data.ResumeData, error) {
+	contents, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return data.ResumeData{}, err
+	}
+	return data.ResumeData{Basics: data.Basics{Name: strings.TrimSpace(string(contents))}}, nil
+}
+
+func (lineCodec) Encode(resume data.ResumeData, writer io.Writer) error {
+	_, err := io.WriteString(writer, resume.Basics.Name+"\n")
+	return err
+}
+
+// Detect recognizes a single line of text without any markup.
+func (lineCodec) Detect(head []byte) bool {
+	text := bytes.TrimSpace(head)
+	return len(text) > 0 && !bytes.ContainsAny(text, "\n<{[=")
+}
+
+func init() {
+	data.RegisterCodec(lineCodec{})
+}
+
+func TestCodecForFilename(t *testing.T) {
+	expected := map[string]string{
+		"resume.xml":  "xml",
+		"resume.JSON": "json",
+		"resume.yaml": "yaml",
+		"resume.yml":  "yaml",
+		"resume.toml": "toml",
+		"resume.line": "line",
+	}
+	for filename, name := range expected {
+		codec, err := data.CodecForFilename(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if codec.Name() != name {
+			t.Fatalf("Expected codec \"%s\" for %s, found \"%s\"", name, filename, codec.Name())
+		}
+	}
+
+	_, err := data.CodecForFilename("resume.txt")
+	if _, ok := err.(data.UnsupportedFormatError); !ok {
+		t.Fatalf("Expected an UnsupportedFormatError, found: %v", err)
+	}
+	if !strings.Contains(err.Error(), "resume.txt") || !strings.Contains(err.Error(), "\".toml\"") {
+		t.Fatalf("Unexpected error message: %s", err)
+	}
+}
+
+func TestCodecs(t *testing.T) {
+	var names []string
+	for _, codec := range data.Codecs() {
+		names = append(names, codec.Name())
+	}
+	if !reflect.DeepEqual(names, []string{"europass-json", "europass-xml", "hropen-json", "hropen-xml", "json", "jsonresume", "line", "toml", "xml", "yaml"}) {
+		t.Fatalf("Unexpected codecs: %v", names)
+	}
+	codec, ok := data.CodecByName("json")
+	if !ok || codec.MimeType() != "application/json" {
+		t.Fatalf("Unexpected JSON codec: %v", codec)
+	}
+	if _, ok := codec.(data.StrictCodec); !ok {
+		t.Fatal("Expected the JSON codec to support strict decoding")
+	}
+}
+
+func TestRegisterCodec_Duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Expected a panic when registering a duplicate codec")
+		}
+	}()
+	data.RegisterCodec(lineCodec{})
+}
+
+func TestFileConversion_AllCodecs(t *testing.T) {
+	originalData := testutils.GenerateTestResumeData()
+	for _, codec := range data.Codecs() {
+		// Foreign formats can't represent all resume data, so they don't round-trip
+		if _, foreign := codec.(data.ReportingCodec); foreign || codec.Name() == "line" {
+			continue
+		}
+		filename := filepath.Join(os.TempDir(), "testresume"+codec.Extensions()[0])
+		testutils.DeleteFileIfExists(t, filename)
+		defer testutils.DeleteFileIfExists(t, filename)
+
+		if err := data.ToFile(originalData, filename); err != nil {
+			t.Fatal(err)
+		}
+		fromFile, err := data.FromFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(originalData, fromFile) {
+			t.Fatalf("Resume data after %s conversion doesn't match the original", codec.Name())
+		}
+	}
+}
+
+func TestReaderWriterConversion_AllCodecs(t *testing.T) {
+	originalData := testutils.GenerateTestResumeData()
+	for _, codec := range data.Codecs() {
+		// Foreign formats can't represent all resume data, so they don't round-trip
+		if _, foreign := codec.(data.ReportingCodec); foreign || codec.Name() == "line" {
+			continue
+		}
+		var buffer bytes.Buffer
+		if err := codec.Encode(originalData, &buffer); err != nil {
+			t.Fatal(err)
+		}
+		fromReader, err := codec.Decode(&buffer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(originalData, fromReader) {
+			t.Fatalf("Resume data after %s stream conversion doesn't match the original", codec.Name())
+		}
+	}
+}
+
+func TestReaderWriter_MatchesStrings(t *testing.T) {
+	originalData := testutils.GenerateTestResumeData()
+	xml, err := data.ToXmlString(originalData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buffer bytes.Buffer
+	if err := data.ToXmlWriter(originalData, &buffer); err != nil {
+		t.Fatal(err)
+	}
+	if buffer.String() != xml {
+		t.Fatal("XML written to a stream doesn't match the XML string")
+	}
+
+	fromReader, err := data.FromJsonReaderStrict(strings.NewReader(`{"basics": {"name": "Peter Gibbons", "nickname": "Pete"}}`))
+	if _, ok := err.(data.DecodeError); !ok {
+		t.Fatalf("Expected a DecodeError from a strict stream, found: %v", err)
+	}
+	fromReader, err = data.FromJsonReader(strings.NewReader(`{"basics": {"name": "Peter Gibbons"}} {}`))
+	if err == nil {
+		t.Fatalf("Expected an error for content after the JSON resume data, found: %+v", fromReader)
+	}
+}
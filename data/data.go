@@ -3,7 +3,11 @@ package data
 import (
 	"encoding/json"
 	"encoding/xml"
-	"io/ioutil"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"strings"
 )
 
 const SCHEMA_VERSION = 1
@@ -28,7 +32,7 @@ type ResumeData struct {
 	//
 	// Obviously, the records in this extra field would be ignored if you used your data file with a standard
 	// JSON-Resume processor.  Otherwise, migration would require you to move any "AdditionalWork" records to the
-	// "Work" field.
+	// "Work" field... which "ToJsonResume()" does automatically.
 	AdditionalWork []Work `xml:"additionalWork" json:"additionalWork"`
 	// WorkLabel is an extra field, not found within the standard JSON-Resume spec.  It is intended to tell templates
 	// how to present the "Work" and "AdditionalWork" sections, when both are used (e.g. "Recent Experience"
@@ -37,9 +41,17 @@ type ResumeData struct {
 	// AdditionalWorkLabel is an extra field, not found within the standard JSON-Resume spec.  It is intended to tell
 	// templates how to present the "Work" and "AdditionalWork" sections, when both are used (e.g. "Recent Experience"
 	// versus "Prior Experience").
-	AdditionalWorkLabel string        `xml:"additionalWorkLabel" json:"additionalWorkLabel"`
-	Education           []Education   `xml:"education" json:"education"`
-	Publications        []Publication `xml:"publications" json:"publications"`
+	AdditionalWorkLabel string `xml:"additionalWorkLabel" json:"additionalWorkLabel"`
+	// WorkGroups is an extra field, not found within the standard JSON-Resume spec.  It generalizes the fixed
+	// "Work" and "AdditionalWork" pair into any number of labeled groups (e.g. "Consulting", "Full-Time" and
+	// "Academic"), presented in the order listed.  Templates should use "AllWorkGroups()", which also includes the
+	// legacy fields.
+	WorkGroups   []WorkGroup   `xml:"workGroups" json:"workGroups"`
+	Volunteer    []Volunteer   `xml:"volunteer" json:"volunteer"`
+	Education    []Education   `xml:"education" json:"education"`
+	Awards       []Award       `xml:"awards" json:"awards"`
+	Certificates []Certificate `xml:"certificates" json:"certificates"`
+	Publications []Publication `xml:"publications" json:"publications"`
 	// AdditionalPublications is an extra field, not found within the standard JSON-Resume spec.  It is intended to
 	// store publications that should be presented differently from those in the main "Publications" field.
 	//
@@ -49,7 +61,7 @@ type ResumeData struct {
 	//
 	// Obviously, the records in this extra field would be ignored if you used your data file with a standard
 	// JSON-Resume processor.  Otherwise, migration would require you to move any "AdditionalPublications" records to
-	// the "Publications" field.
+	// the "Publications" field... which "ToJsonResume()" does automatically.
 	AdditionalPublications []Publication `xml:"additionalPublications" json:"additionalPublications"`
 	// PublicationsLabel is an extra field, not found within the standard JSON-Resume spec.  It is intended to tell
 	// templates how to present the "Publications" and "AdditionalPublications" sections, when both are used
@@ -58,8 +70,18 @@ type ResumeData struct {
 	// AdditionalPublicationsLabel is an extra field, not found within the standard JSON-Resume spec.  It is intended
 	// to tell templates how to present the "Publications" and "AdditionalPublications" sections, when both are used
 	// (e.g. "Publications (Author)" versus "Publications (Technical Reviewer)").
-	AdditionalPublicationsLabel string  `xml:"additionalPublicationsLabel" json:"additionalPublicationsLabel"`
-	Skills                      []Skill `xml:"skills" json:"skills"`
+	AdditionalPublicationsLabel string `xml:"additionalPublicationsLabel" json:"additionalPublicationsLabel"`
+	// PublicationGroups is an extra field, not found within the standard JSON-Resume spec.  It generalizes the
+	// fixed "Publications" and "AdditionalPublications" pair into any number of labeled groups (e.g. "Author",
+	// "Technical Reviewer" and "Editor"), presented in the order listed.  Templates should use
+	// "AllPublicationGroups()", which also includes the legacy fields.
+	PublicationGroups []PublicationGroup `xml:"publicationGroups" json:"publicationGroups"`
+	Skills            []Skill            `xml:"skills" json:"skills"`
+	Languages         []Language         `xml:"languages" json:"languages"`
+	Interests         []Interest         `xml:"interests" json:"interests"`
+	References        []Reference        `xml:"references" json:"references"`
+	Projects          []Project          `xml:"projects" json:"projects"`
+	Extra             Extra              `xml:",any" json:"-"`
 }
 
 // Basics is a container for top-level resume data.  These fields could just as well hang off the parent "ResumeData"
@@ -80,10 +102,11 @@ type Basics struct {
 	// Obviously, the records in this extra field would be ignored if you used your data file with a standard
 	// JSON-Resume processor.  Once the other JSON-Resume processors gain mature support for HTML and/or Markdown
 	// line-break formatting within field values, then perhaps you could migrate "Highlights" data to within the
-	// "Summary" field.
+	// "Summary" field.  ResumeFodder templates already support inline Markdown within both fields.
 	Highlights []string        `xml:"highlights" json:"highlights"`
 	Location   Location        `xml:"location" json:"location"`
 	Profiles   []SocialProfile `xml:"profiles" json:"profiles"`
+	Extra      Extra           `xml:",any" json:"-"`
 }
 
 type Location struct {
@@ -92,23 +115,48 @@ type Location struct {
 	City        string `xml:"city" json:"city"`
 	CountryCode string `xml:"countryCode" json:"countryCode"`
 	Region      string `xml:"region" json:"region"`
+	Extra       Extra  `xml:",any" json:"-"`
 }
 
 type SocialProfile struct {
 	Network  string `xml:"network" json:"network"`
 	Username string `xml:"username" json:"username"`
 	Url      string `xml:"url" json:"url"`
+	Extra    Extra  `xml:",any" json:"-"`
 }
 
 type Work struct {
 	// TODO: Perhaps job listings should have 'City' and 'Region' extension fields, as this is commonly found on resumes
-	Company    string   `xml:"company" json:"company"`
-	Position   string   `xml:"position" json:"position"`
-	Website    string   `xml:"website" json:"website"`
-	StartDate  string   `xml:"startDate" json:"startDate"`
-	EndDate    string   `xml:"endDate" json:"endDate"`
-	Summary    string   `xml:"summary" json:"summary"`
-	Highlights []string `xml:"highlights" json:"highlights"`
+	Company    string      `xml:"company" json:"company"`
+	Position   string      `xml:"position" json:"position"`
+	Website    string      `xml:"website" json:"website"`
+	StartDate  Date        `xml:"startDate" json:"startDate"`
+	EndDate    Date        `xml:"endDate" json:"endDate"`
+	Summary    string      `xml:"summary" json:"summary"`
+	Highlights []Highlight `xml:"highlights" json:"highlights"`
+	// Roles is an extra field, not found within the standard JSON-Resume spec.  It lists each position held at the
+	// same employer (e.g. after a promotion), so that templates can present them together under one heading rather
+	// than as separate employers.  See "AllRoles()" and "Tenure()".
+	Roles []Role `xml:"roles" json:"roles"`
+	Tags  Tags   `xml:"tags" json:"tags"`
+	Extra Extra  `xml:",any" json:"-"`
+}
+
+type WorkGroup struct {
+	Name  string `xml:"name" json:"name"`
+	Work  []Work `xml:"work" json:"work"`
+	Extra Extra  `xml:",any" json:"-"`
+}
+
+type Volunteer struct {
+	Organization string   `xml:"organization" json:"organization"`
+	Position     string   `xml:"position" json:"position"`
+	Url          string   `xml:"url" json:"url"`
+	StartDate    Date     `xml:"startDate" json:"startDate"`
+	EndDate      Date     `xml:"endDate" json:"endDate"`
+	Summary      string   `xml:"summary" json:"summary"`
+	Highlights   []string `xml:"highlights" json:"highlights"`
+	Extra        Extra    `xml:",any" json:"-"`
 }
 
 type Education struct {
@@ -116,33 +164,127 @@ type Education struct {
 	Institution string   `xml:"institution" json:"institution"`
 	Area        string   `xml:"area" json:"area"`
 	StudyType   string   `xml:"studyType" json:"studyType"`
-	StartDate   string   `xml:"startDate" json:"startDate"`
-	EndDate     string   `xml:"endDate" json:"endDate"`
+	StartDate   Date     `xml:"startDate" json:"startDate"`
+	EndDate     Date     `xml:"endDate" json:"endDate"`
 	GPA         string   `xml:"gpa" json:"gpa"`
 	Courses     []string `xml:"courses" json:"courses"`
+	Tags        Tags     `xml:"tags" json:"tags"`
+	Extra       Extra    `xml:",any" json:"-"`
+}
+
+type Award struct {
+	Title   string `xml:"title" json:"title"`
+	Date    Date   `xml:"date" json:"date"`
+	Awarder string `xml:"awarder" json:"awarder"`
+	Summary string `xml:"summary" json:"summary"`
+	Extra   Extra  `xml:",any" json:"-"`
+}
+
+type Certificate struct {
+	Name   string `xml:"name" json:"name"`
+	Date   Date   `xml:"date" json:"date"`
+	Issuer string `xml:"issuer" json:"issuer"`
+	Url    string `xml:"url" json:"url"`
+	Extra  Extra  `xml:",any" json:"-"`
 }
 
 type PublicationGroup struct {
 	Name         string        `xml:"name" json:"name"`
 	Publications []Publication `xml:"publications" json:"publications"`
+	Extra        Extra         `xml:",any" json:"-"`
 }
 
 type Publication struct {
 	Name        string `xml:"name" json:"name"`
 	Publisher   string `xml:"publisher" json:"publisher"`
-	ReleaseDate string `xml:"releaseDate" json:"releaseDate"`
+	ReleaseDate Date   `xml:"releaseDate" json:"releaseDate"`
 	Website     string `xml:"website" json:"website"`
 	Summary     string `xml:"summary" json:"summary"`
 	// ISBN is an extra field, not found within the standard JSON-Resume spec.  Obviously, this value will be
 	// ignored if you used your data file with another JSON-Resume processor.  You could perhaps migrate by
 	// cramming this info into the "Summary" field.
-	ISBN string `xml:"isbn" json:"isbn"`
+	ISBN  string `xml:"isbn" json:"isbn"`
+	Tags  Tags   `xml:"tags" json:"tags"`
+	Extra Extra  `xml:",any" json:"-"`
 }
 
 type Skill struct {
 	Name     string   `xml:"name" json:"name"`
 	Level    string   `xml:"level" json:"level"`
 	Keywords []string `xml:"keywords" json:"keywords"`
+	Tags     Tags     `xml:"tags" json:"tags"`
+	Extra    Extra    `xml:",any" json:"-"`
+}
+
+type Language struct {
+	Language string `xml:"language" json:"language"`
+	Fluency  string `xml:"fluency" json:"fluency"`
+	Extra    Extra  `xml:",any" json:"-"`
+}
+
+type Interest struct {
+	Name     string   `xml:"name" json:"name"`
+	Keywords []string `xml:"keywords" json:"keywords"`
+	Extra    Extra    `xml:",any" json:"-"`
+}
+
+type Reference struct {
+	Name      string `xml:"name" json:"name"`
+	Reference string `xml:"reference" json:"reference"`
+	Extra     Extra  `xml:",any" json:"-"`
+}
+
+type Project struct {
+	Name        string   `xml:"name" json:"name"`
+	Description string   `xml:"description" json:"description"`
+	Highlights  []string `xml:"highlights" json:"highlights"`
+	Keywords    []string `xml:"keywords" json:"keywords"`
+	StartDate   Date     `xml:"startDate" json:"startDate"`
+	EndDate     Date     `xml:"endDate" json:"endDate"`
+	Url         string   `xml:"url" json:"url"`
+	Roles       []string `xml:"roles" json:"roles"`
+	Entity      string   `xml:"entity" json:"entity"`
+	Type        string   `xml:"type" json:"type"`
+	Extra       Extra    `xml:",any" json:"-"`
+}
+
+// AllWorkGroups returns the complete work history as an ordered list of labeled groups, for templates to present
+// each group under its own heading.  The legacy "Work" and "AdditionalWork" fields come first (labeled with
+// "WorkLabel" and "AdditionalWorkLabel"), followed by the entries in "WorkGroups".  Empty groups are skipped.
+func (data ResumeData) AllWorkGroups() []WorkGroup {
+	var groups []WorkGroup
+	if len(data.Work) > 0 {
+		groups = append(groups, WorkGroup{Name: data.WorkLabel, Work: data.Work})
+	}
+	if len(data.AdditionalWork) > 0 {
+		groups = append(groups, WorkGroup{Name: data.AdditionalWorkLabel, Work: data.AdditionalWork})
+	}
+	for _, group := range data.WorkGroups {
+		if len(group.Work) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// AllPublicationGroups returns all publications as an ordered list of labeled groups, for templates to present
+// each group under its own heading.  The legacy "Publications" and "AdditionalPublications" fields come first
+// (labeled with "PublicationsLabel" and "AdditionalPublicationsLabel"), followed by the entries in
+// "PublicationGroups".  Empty groups are skipped.
+func (data ResumeData) AllPublicationGroups() []PublicationGroup {
+	var groups []PublicationGroup
+	if len(data.Publications) > 0 {
+		groups = append(groups, PublicationGroup{Name: data.PublicationsLabel, Publications: data.Publications})
+	}
+	if len(data.AdditionalPublications) > 0 {
+		groups = append(groups, PublicationGroup{Name: data.AdditionalPublicationsLabel, Publications: data.AdditionalPublications})
+	}
+	for _, group := range data.PublicationGroups {
+		if len(group.Publications) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
 }
 
 // NewResumeData initializes a ResumeData struct, with ALL nested structs initialized
@@ -163,10 +305,42 @@ func NewResumeData() ResumeData {
 		},
 		Work: []Work{
 			{
-				Highlights: []string{""},
+				Highlights: []Highlight{{}},
+				Roles: []Role{
+					{
+						Highlights: []Highlight{{}},
+					},
+				},
+				Tags: Tags{""},
 			},
 		},
 		AdditionalWork: []Work{
+			{
+				Highlights: []Highlight{{}},
+				Roles: []Role{
+					{
+						Highlights: []Highlight{{}},
+					},
+				},
+				Tags: Tags{""},
+			},
+		},
+		WorkGroups: []WorkGroup{
+			{
+				Work: []Work{
+					{
+						Highlights: []Highlight{{}},
+						Roles: []Role{
+							{
+								Highlights: []Highlight{{}},
+							},
+						},
+						Tags: Tags{""},
+					},
+				},
+			},
+		},
+		Volunteer: []Volunteer{
 			{
 				Highlights: []string{""},
 			},
@@ -174,38 +348,70 @@ func NewResumeData() ResumeData {
 		Education: []Education{
 			{
 				Courses: []string{""},
+				Tags:    Tags{""},
+			},
+		},
+		Awards:       []Award{{}},
+		Certificates: []Certificate{{}},
+		Publications: []Publication{
+			{
+				Tags: Tags{""},
+			},
+		},
+		AdditionalPublications: []Publication{
+			{
+				Tags: Tags{""},
+			},
+		},
+		PublicationGroups: []PublicationGroup{
+			{
+				Publications: []Publication{
+					{
+						Tags: Tags{""},
+					},
+				},
 			},
 		},
-		Publications:           []Publication{{}},
-		AdditionalPublications: []Publication{{}},
 		Skills: []Skill{
+			{
+				Keywords: []string{""},
+				Tags:     Tags{""},
+			},
+		},
+		Languages: []Language{{}},
+		Interests: []Interest{
 			{
 				Keywords: []string{""},
 			},
 		},
+		References: []Reference{{}},
+		Projects: []Project{
+			{
+				Highlights: []string{""},
+				Keywords:   []string{""},
+				Roles:      []string{""},
+			},
+		},
 	}
 }
 
 // FromXmlString loads a ResumeData struct from a string of XML text.
 func FromXmlString(xmlString string) (ResumeData, error) {
-	return fromXml([]byte(xmlString))
+	return FromXmlReader(strings.NewReader(xmlString))
 }
 
 // FromXmlFile loads a ResumeData struct from an XML file.
 func FromXmlFile(xmlFilename string) (ResumeData, error) {
-	bytes, err := ioutil.ReadFile(xmlFilename)
-	if err != nil {
-		return ResumeData{}, err
-	}
-	return fromXml(bytes)
+	return readFile(xmlFilename, FromXmlReader)
 }
 
-// fromXml is a private function that provides the core logic for `FromXmlString` and `FromXmlFile`.
-func fromXml(xmlBytes []byte) (ResumeData, error) {
+// FromXmlReader loads a ResumeData struct from a stream of XML text (e.g. an HTTP request body, or standard input).
+// This provides the core logic for `FromXmlString` and `FromXmlFile`.
+func FromXmlReader(reader io.Reader) (ResumeData, error) {
 	var data ResumeData
-	err := xml.Unmarshal(xmlBytes, &data)
+	err := xml.NewDecoder(reader).Decode(&data)
 	if err == nil {
-		// The marshal process in `toXml()` will use field tags to populate the `ResumeData.XMLName` field
+		// The marshal process in `ToXmlWriter()` will use field tags to populate the `ResumeData.XMLName` field
 		// with `resume`.  When unmarshalling from XML, we likewise strip this field value back off... to
 		// better facilitate equality comparison between `ResumeData` structs (e.g. in unit testing).
 		data.XMLName.Local = ""
@@ -215,73 +421,96 @@ func fromXml(xmlBytes []byte) (ResumeData, error) {
 
 // ToXmlString writes a ResumeData struct to a string of XML text.
 func ToXmlString(data ResumeData) (string, error) {
-	xmlBytes, err := toXml(data)
-	if err != nil {
-		return "", err
-	}
-	return string(xmlBytes[:]), nil
+	return writeString(data, ToXmlWriter)
 }
 
 // ToXmlFile writes a ResumeData struct to an XML file.
 func ToXmlFile(data ResumeData, xmlFilename string) error {
-	xmlBytes, err := toXml(data)
-	if err != nil {
-		return err
-	}
-	if err := ioutil.WriteFile(xmlFilename, xmlBytes, 0644); err != nil {
-		return err
-	}
-	return nil
+	return writeFile(data, xmlFilename, ToXmlWriter)
 }
 
-// toXml is a private function that provides the core logic for `ToXmlString` and `ToXmlFile`.
-func toXml(data ResumeData) ([]byte, error) {
-	return xml.MarshalIndent(data, "", "  ")
+// ToXmlWriter writes a ResumeData struct to a stream of XML text (e.g. an HTTP response, or standard output).  This
+// provides the core logic for `ToXmlString` and `ToXmlFile`.
+func ToXmlWriter(data ResumeData, writer io.Writer) error {
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	return encoder.Encode(withoutShadowedExtra(reflect.ValueOf(data)).Interface())
 }
 
 // FromJsonString loads a ResumeData struct from a string of JSON text.
 func FromJsonString(jsonString string) (ResumeData, error) {
-	return fromJson([]byte(jsonString))
+	return FromJsonReader(strings.NewReader(jsonString))
 }
 
 // FromJsonFile loads a ResumeData struct from a JSON file.
 func FromJsonFile(jsonFilename string) (ResumeData, error) {
-	bytes, err := ioutil.ReadFile(jsonFilename)
-	if err != nil {
-		return ResumeData{}, err
-	}
-	return fromJson(bytes)
+	return readFile(jsonFilename, FromJsonReader)
 }
 
-// fromJson is a private function that provides the core logic for `FromJsonString` and `FromJsonFile`.
-func fromJson(jsonBytes []byte) (ResumeData, error) {
+// FromJsonReader loads a ResumeData struct from a stream of JSON text.  This provides the core logic for
+// `FromJsonString` and `FromJsonFile`.
+func FromJsonReader(reader io.Reader) (ResumeData, error) {
 	var data ResumeData
-	err := json.Unmarshal(jsonBytes, &data)
-	return data, err
+	decoder := json.NewDecoder(reader)
+	if err := decoder.Decode(&data); err != nil {
+		return data, err
+	}
+	// Unlike "json.Unmarshal()", a decoder stops after the first value... so check that nothing else follows it
+	if _, err := decoder.Token(); err != io.EOF {
+		return data, errors.New("Unexpected content after the end of the JSON resume data")
+	}
+	return data, nil
 }
 
 // ToJsonString writes a ResumeData struct to a string of JSON text.
 func ToJsonString(data ResumeData) (string, error) {
-	jsonBytes, err := toJson(data)
-	if err != nil {
-		return "", err
-	}
-	return string(jsonBytes[:]), nil
+	return writeString(data, ToJsonWriter)
 }
 
 // ToJsonFile writes a ResumeData struct to a JSON file.
 func ToJsonFile(data ResumeData, jsonFilename string) error {
-	jsonBytes, err := toJson(data)
+	return writeFile(data, jsonFilename, ToJsonWriter)
+}
+
+// ToJsonWriter writes a ResumeData struct to a stream of JSON text.  This provides the core logic for `ToJsonString`
+// and `ToJsonFile`.
+func ToJsonWriter(data ResumeData, writer io.Writer) error {
+	jsonBytes, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(jsonBytes)
+	return err
+}
+
+// readFile opens a file and passes its contents to a reader-based decoding function.
+func readFile(filename string, decode func(io.Reader) (ResumeData, error)) (ResumeData, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return ResumeData{}, err
+	}
+	defer file.Close()
+	return decode(file)
+}
+
+// writeFile creates (or truncates) a file, and writes resume data into it with a writer-based encoding function.
+func writeFile(data ResumeData, filename string, encode func(ResumeData, io.Writer) error) error {
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
 		return err
 	}
-	if err := ioutil.WriteFile(jsonFilename, jsonBytes, 0644); err != nil {
+	if err := encode(data, file); err != nil {
+		file.Close()
 		return err
 	}
-	return nil
+	return file.Close()
 }
 
-// toJson is a private function that provides the core logic for `ToJsonString` and `ToJsonFile`.
-func toJson(data ResumeData) ([]byte, error) {
-	return json.MarshalIndent(data, "", "  ")
+// writeString writes resume data to a string, with a writer-based encoding function.
+func writeString(data ResumeData, encode func(ResumeData, io.Writer) error) (string, error) {
+	var builder strings.Builder
+	if err := encode(data, &builder); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
 }
@@ -140,3 +140,41 @@ func TestJsonFileConversion(t *testing.T) {
 		t.Fatal("Resume data after JSON conversion doesn't match the original")
 	}
 }
+
+func TestJsonResumeSections(t *testing.T) {
+	// A trimmed-down file in the standard JSON-Resume format, using the sections beyond "work" and "education"
+	json := `{
+  "volunteer": [{"organization": "Habitat for Humanity", "position": "Volunteer", "highlights": ["Built a house"]}],
+  "awards": [{"title": "Employee of the Month", "date": "1998-11-01", "awarder": "Initech"}],
+  "certificates": [{"name": "Y2K Specialist", "issuer": "Bobs Consulting"}],
+  "languages": [{"language": "English", "fluency": "Native speaker"}],
+  "interests": [{"name": "Fishing", "keywords": ["Lake"]}],
+  "references": [{"name": "Lawrence", "reference": "A heck of a guy."}],
+  "projects": [{"name": "Penny Shaving", "roles": ["Co-conspirator"], "entity": "Initech", "type": "application"}]
+}`
+	resume, err := data.FromJsonString(json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resume.Volunteer) != 1 || resume.Volunteer[0].Highlights[0] != "Built a house" {
+		t.Fatalf("Volunteer section not loaded: %+v", resume.Volunteer)
+	}
+	if len(resume.Awards) != 1 || resume.Awards[0].Awarder != "Initech" {
+		t.Fatalf("Awards section not loaded: %+v", resume.Awards)
+	}
+	if len(resume.Certificates) != 1 || resume.Certificates[0].Issuer != "Bobs Consulting" {
+		t.Fatalf("Certificates section not loaded: %+v", resume.Certificates)
+	}
+	if len(resume.Languages) != 1 || resume.Languages[0].Fluency != "Native speaker" {
+		t.Fatalf("Languages section not loaded: %+v", resume.Languages)
+	}
+	if len(resume.Interests) != 1 || resume.Interests[0].Keywords[0] != "Lake" {
+		t.Fatalf("Interests section not loaded: %+v", resume.Interests)
+	}
+	if len(resume.References) != 1 || resume.References[0].Name != "Lawrence" {
+		t.Fatalf("References section not loaded: %+v", resume.References)
+	}
+	if len(resume.Projects) != 1 || resume.Projects[0].Roles[0] != "Co-conspirator" {
+		t.Fatalf("Projects section not loaded: %+v", resume.Projects)
+	}
+}
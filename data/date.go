@@ -0,0 +1,158 @@
+package data
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Date is a resume date, in one of the formats allowed by the JSON-Resume spec:  a year ("2014"), a year and month
+// ("2014-06"), or a full date ("2014-06-01").  The value "present" may also be used (typically for the end date of a
+// current job), and a blank value means that the date is unknown or not applicable.
+//
+// Because Date is a string type, it marshals to and from XML and JSON exactly as it was written... so a file that
+// only specifies a year will be written back out with only a year.
+type Date string
+
+// Present is the explicit value for an ongoing date range.  When parsing, the comparison is case-insensitive.
+const Present Date = "present"
+
+// DatePrecision indicates how much of a date was specified.
+type DatePrecision int
+
+const (
+	PrecisionNone DatePrecision = iota
+	PrecisionYear
+	PrecisionMonth
+	PrecisionDay
+)
+
+var datePattern = regexp.MustCompile(`^\d{4}(-\d{2}(-\d{2})?)?$`)
+
+var dateLayouts = map[DatePrecision]string{
+	PrecisionYear:  "2006",
+	PrecisionMonth: "2006-01",
+	PrecisionDay:   "2006-01-02",
+}
+
+// IsPresent returns true if the date is the explicit "present" value.
+func (date Date) IsPresent() bool {
+	return strings.EqualFold(strings.TrimSpace(string(date)), string(Present))
+}
+
+// IsBlank returns true if no date was specified.
+func (date Date) IsBlank() bool {
+	return strings.TrimSpace(string(date)) == ""
+}
+
+// Precision returns how much of the date was specified.  Blank, "present" and unparseable values have no
+// precision.
+func (date Date) Precision() DatePrecision {
+	if !datePattern.MatchString(string(date)) {
+		return PrecisionNone
+	}
+	precision := DatePrecision(strings.Count(string(date), "-") + 1)
+	if _, err := time.Parse(dateLayouts[precision], string(date)); err != nil {
+		return PrecisionNone
+	}
+	return precision
+}
+
+// Valid returns true if the date is blank, "present", or in one of the JSON-Resume formats.
+func (date Date) Valid() bool {
+	return date.IsBlank() || date.IsPresent() || date.Precision() != PrecisionNone
+}
+
+// Time returns the date as a time.Time value, with any unspecified month or day defaulting to the first.  The
+// second return value is false if the date is blank, "present" or unparseable.
+func (date Date) Time() (time.Time, bool) {
+	precision := date.Precision()
+	if precision == PrecisionNone {
+		return time.Time{}, false
+	}
+	parsed, err := time.Parse(dateLayouts[precision], string(date))
+	return parsed, err == nil
+}
+
+// Compare returns -1, 0 or +1 depending on whether this date is before, the same as, or after another.  Dates are
+// only compared to the precision that both share, so "2014" is considered the same as "2014-06".  A "present" date
+// is after any other date.  The second return value is false if either date is blank or unparseable.
+func (date Date) Compare(other Date) (int, bool) {
+	if date.IsPresent() || other.IsPresent() {
+		if date.IsPresent() && other.IsPresent() {
+			return 0, true
+		} else if date.IsPresent() {
+			return 1, other.Precision() != PrecisionNone
+		}
+		return -1, date.Precision() != PrecisionNone
+	}
+	precision, otherPrecision := date.Precision(), other.Precision()
+	if precision == PrecisionNone || otherPrecision == PrecisionNone {
+		return 0, false
+	}
+	if otherPrecision < precision {
+		precision = otherPrecision
+	}
+	// With fixed-width numeric components, comparing the strings up to the shared precision compares the dates.
+	length := len(dateLayouts[precision])
+	return strings.Compare(string(date)[:length], string(other)[:length]), true
+}
+
+// Format renders the date for display.  The layout (in time.Format style) is used for dates with month or day
+// precision, and year-only dates are rendered as just the year.  A "present" date is rendered as "Present", and
+// any unparseable value is returned unchanged.
+func (date Date) Format(layout string) string {
+	if date.IsPresent() {
+		return "Present"
+	}
+	parsed, ok := date.Time()
+	if !ok {
+		return string(date)
+	}
+	if date.Precision() == PrecisionYear {
+		return parsed.Format("2006")
+	}
+	return parsed.Format(layout)
+}
+
+// foreignDateLayouts are date formats commonly found in data exported by other tools, each mapped to the precision
+// that it specifies.
+var foreignDateLayouts = []struct {
+	layout    string
+	precision DatePrecision
+}{
+	{"2006-01-02", PrecisionDay},
+	{"2006-01", PrecisionMonth},
+	{"2006", PrecisionYear},
+	{"Jan 2006", PrecisionMonth},
+	{"January 2006", PrecisionMonth},
+	{"Jan 2, 2006", PrecisionDay},
+	{"January 2, 2006", PrecisionDay},
+	{"2 Jan 2006", PrecisionDay},
+	{"2 January 2006", PrecisionDay},
+	{"1/2006", PrecisionMonth},
+	{"1/2/2006", PrecisionDay},
+	{"1/2/06", PrecisionDay},
+	{"2006/01/02", PrecisionDay},
+	{"2006-01-02T15:04:05Z07:00", PrecisionDay},
+}
+
+// NormalizeDate converts a date written in a format commonly used by other tools (e.g. "Feb 2019", "Mar 15, 2018"
+// or "3/15/2018") into a JSON-Resume date, keeping the precision that was specified.  The words "present" and
+// "current" become "present".  The second return value is false if the text isn't recognized, in which case it is
+// returned unchanged.
+func NormalizeDate(text string) (Date, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", true
+	}
+	if strings.EqualFold(text, "present") || strings.EqualFold(text, "current") {
+		return Present, true
+	}
+	for _, format := range foreignDateLayouts {
+		if parsed, err := time.Parse(format.layout, text); err == nil {
+			return Date(parsed.Format(dateLayouts[format.precision])), true
+		}
+	}
+	return Date(text), false
+}
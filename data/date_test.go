@@ -0,0 +1,97 @@
+package data_test
+
+import (
+	"encoding/json"
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"testing"
+)
+
+func TestDatePrecision(t *testing.T) {
+	tests := []struct {
+		date      data.Date
+		precision data.DatePrecision
+		valid     bool
+	}{
+		{"2014", data.PrecisionYear, true},
+		{"2014-06", data.PrecisionMonth, true},
+		{"2014-06-01", data.PrecisionDay, true},
+		{"2014-13", data.PrecisionNone, false},
+		{"06/2014", data.PrecisionNone, false},
+		{"Present", data.PrecisionNone, true},
+		{"", data.PrecisionNone, true},
+	}
+	for _, test := range tests {
+		if precision := test.date.Precision(); precision != test.precision {
+			t.Fatalf("Expected precision %d for \"%s\", found %d", test.precision, test.date, precision)
+		}
+		if valid := test.date.Valid(); valid != test.valid {
+			t.Fatalf("Expected validity %t for \"%s\", found %t", test.valid, test.date, valid)
+		}
+	}
+}
+
+func TestDateCompare(t *testing.T) {
+	tests := []struct {
+		date, other data.Date
+		comparison  int
+	}{
+		{"2014", "2014-06", 0},
+		{"2013-12", "2014", -1},
+		{"2014-06-02", "2014-06-01", 1},
+		{"present", "2014-06-01", 1},
+		{"2014", data.Present, -1},
+	}
+	for _, test := range tests {
+		comparison, ok := test.date.Compare(test.other)
+		if !ok || comparison != test.comparison {
+			t.Fatalf("Expected \"%s\" compared to \"%s\" to be %d, found %d", test.date, test.other, test.comparison, comparison)
+		}
+	}
+	if _, ok := data.Date("").Compare("2014"); ok {
+		t.Fatal("Expected a blank date to be incomparable")
+	}
+}
+
+func TestDateFormat(t *testing.T) {
+	tests := []struct {
+		date     data.Date
+		expected string
+	}{
+		{"2014", "2014"},
+		{"2014-06", "June 2014"},
+		{"2014-06-15", "June 2014"},
+		{"present", "Present"},
+		{"Summer 2014", "Summer 2014"},
+	}
+	for _, test := range tests {
+		if formatted := test.date.Format("January 2006"); formatted != test.expected {
+			t.Fatalf("Expected \"%s\" to format as \"%s\", found \"%s\"", test.date, test.expected, formatted)
+		}
+	}
+}
+
+func TestDatePrecisionRoundTrip(t *testing.T) {
+	work := data.Work{Company: "Initech", StartDate: "1998", EndDate: "2002-05"}
+	jsonBytes, err := json.Marshal(work)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resume, err := data.FromJsonString(`{"work": [` + string(jsonBytes) + `]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resume.Work[0].StartDate != "1998" || resume.Work[0].EndDate != "2002-05" {
+		t.Fatalf("Date precision changed during round trip: %+v", resume.Work[0])
+	}
+	xml, err := data.ToXmlString(resume)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromXml, err := data.FromXmlString(xml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromXml.Work[0].StartDate != "1998" || fromXml.Work[0].EndDate != "2002-05" {
+		t.Fatalf("Date precision changed during XML round trip: %+v", fromXml.Work[0])
+	}
+}
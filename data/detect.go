@@ -0,0 +1,198 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Detector is implemented by codecs that can recognize their own format from the leading bytes of a file, for files
+// whose names don't have a registered extension (e.g. ".txt" uploads, temporary files or standard input).  The
+// leading bytes are passed with any UTF-8 byte order mark and leading whitespace already removed.
+type Detector interface {
+	Detect(head []byte) bool
+}
+
+// detectLength is the number of leading bytes that are inspected when detecting a format.
+const detectLength = 512
+
+// AmbiguousFormatError is returned when the contents of a file could belong to more than one registered format.
+type AmbiguousFormatError struct {
+	Filename string
+	Formats  []string
+}
+
+func (err AmbiguousFormatError) Error() string {
+	name := err.Filename
+	if name == "" {
+		name = "the resume data"
+	}
+	return fmt.Sprintf("Could not determine the format of %s, as its contents could be any of: %s.  Use a filename "+
+		"extension to choose one.", name, strings.Join(err.Formats, ", "))
+}
+
+var (
+	yamlKeyPattern   = regexp.MustCompile(`^["']?[A-Za-z_$][^:=\s]*["']?\s*:(\s|$)`)
+	tomlTablePattern = regexp.MustCompile(`^\[\[?\s*[A-Za-z0-9_."' -]+\s*\]\]?\s*(#.*)?$`)
+	tomlKeyPattern   = regexp.MustCompile(`^["']?[A-Za-z0-9_.-]+["']?\s*=`)
+)
+
+// foreignRoots lists the XML root elements and leading JSON properties of foreign formats (e.g. "SkillsPassport" for
+// Europass), which the built-in XML and JSON formats don't claim when detecting.
+var foreignRoots = map[string]bool{}
+
+// DetectFormat returns the registered format whose "Detector" recognizes the leading bytes of a file.  An
+// "UnsupportedFormatError" is returned if no format recognizes them, and an "AmbiguousFormatError" if several do.
+//
+// The built-in formats are recognized by:  an XML prolog ("<?xml") or "<resume>" root element; a JSON object; a
+// YAML document marker ("---" or "%YAML"), or a first line of the form "key:"; and a TOML table header ("[basics]")
+// or a first line of the form "key =".  XML and JSON documents belonging to a foreign format (e.g. Europass) are
+// recognized by their root element or leading property instead... or for JSON Resume, by a leading "$schema"
+// property naming the JSON Resume schema.
+func DetectFormat(head []byte) (Codec, error) {
+	return detectFormat(head, "")
+}
+
+// DetectFileFormat returns the registered format of a file.  The format is chosen by the filename's extension if
+// possible, and otherwise by inspecting the file's contents (see "DetectFormat()").
+func DetectFileFormat(filename string) (Codec, error) {
+	if codec, err := CodecForFilename(filename); err == nil {
+		return codec, nil
+	}
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	head := make([]byte, detectLength)
+	count, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	return detectFormat(head[:count], filename)
+}
+
+// FromReader loads a ResumeData struct from a stream in any registered format, detected by inspecting the stream's
+// leading bytes (see "DetectFormat()").
+func FromReader(reader io.Reader) (ResumeData, error) {
+	buffered := bufio.NewReaderSize(reader, detectLength)
+	head, err := buffered.Peek(detectLength)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return ResumeData{}, err
+	}
+	codec, err := DetectFormat(head)
+	if err != nil {
+		return ResumeData{}, err
+	}
+	return codec.Decode(buffered)
+}
+
+func detectFormat(head []byte, filename string) (Codec, error) {
+	head = bytes.TrimPrefix(head, []byte("\xef\xbb\xbf"))
+	head = bytes.TrimLeft(head, " \t\r\n")
+	var matches []Codec
+	for _, codec := range Codecs() {
+		if detector, ok := codec.(Detector); ok && detector.Detect(head) {
+			matches = append(matches, codec)
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0], nil
+	} else if len(matches) > 1 {
+		var names []string
+		for _, codec := range matches {
+			names = append(names, codec.Name())
+		}
+		return nil, AmbiguousFormatError{Filename: filename, Formats: names}
+	}
+	return nil, UnsupportedFormatError{Filename: filename, Detected: true}
+}
+
+// firstLine returns the first line of text that isn't blank or a "#" comment, with surrounding whitespace removed.
+func firstLine(head []byte) string {
+	for _, line := range strings.Split(string(head), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return line
+		}
+	}
+	return ""
+}
+
+// xmlRoot returns the name of the root element in the leading bytes of an XML document, skipping any prolog,
+// comments and document type declaration.  A blank name is returned if the root element isn't found.
+func xmlRoot(head []byte) string {
+	decoder := xml.NewDecoder(bytes.NewReader(head))
+	for {
+		token, err := decoder.RawToken()
+		if err != nil {
+			return ""
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local
+		}
+	}
+}
+
+// jsonFirstKey returns the first property name of the JSON object in the leading bytes of a document, or a blank
+// name if there isn't one.
+func jsonFirstKey(head []byte) string {
+	key, _ := jsonFirstProperty(head)
+	return key
+}
+
+// jsonFirstProperty returns the first property name of the JSON object in the leading bytes of a document, along
+// with its value if that is a string.  Both are blank if there is no such property.
+func jsonFirstProperty(head []byte) (string, string) {
+	decoder := json.NewDecoder(bytes.NewReader(head))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return "", ""
+	}
+	token, err := decoder.Token()
+	key, ok := token.(string)
+	if err != nil || !ok {
+		return "", ""
+	}
+	if token, err := decoder.Token(); err == nil {
+		if value, ok := token.(string); ok {
+			return key, value
+		}
+	}
+	return key, ""
+}
+
+func (xmlCodec) Detect(head []byte) bool {
+	if foreignRoots[xmlRoot(head)] {
+		return false
+	}
+	if bytes.HasPrefix(head, []byte("<?xml")) {
+		return true
+	}
+	if !bytes.HasPrefix(head, []byte("<resume")) || len(head) == len("<resume") {
+		return false
+	}
+	next := head[len("<resume")]
+	return next == '>' || next == '/' || next == ' ' || next == '\t' || next == '\r' || next == '\n'
+}
+
+func (jsonCodec) Detect(head []byte) bool {
+	return bytes.HasPrefix(head, []byte("{")) && !foreignRoots[jsonFirstKey(head)] && !(jsonResumeCodec{}).Detect(head)
+}
+
+func (yamlCodec) Detect(head []byte) bool {
+	if bytes.HasPrefix(head, []byte("---")) || bytes.HasPrefix(head, []byte("%YAML")) {
+		return true
+	}
+	return yamlKeyPattern.MatchString(firstLine(head))
+}
+
+func (tomlCodec) Detect(head []byte) bool {
+	line := firstLine(head)
+	return tomlTablePattern.MatchString(line) || tomlKeyPattern.MatchString(line)
+}
@@ -0,0 +1,109 @@
+package data_test
+
+import (
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+	expected := map[string]string{
+		`<?xml version="1.0" encoding="UTF-8"?><resume></resume>`:         "xml",
+		"\xef\xbb\xbf\n  <resume>\n</resume>":                             "xml",
+		`<resume version="1"/>`:                                           "xml",
+		`  {"basics": {"name": "Peter Gibbons"}}`:                         "json",
+		"---\nbasics:\n  name: Peter Gibbons\n":                           "yaml",
+		"%YAML 1.2\n---\nbasics: {}\n":                                    "yaml",
+		"# My resume\nbasics:\n  name: Peter Gibbons\n":                   "yaml",
+		"[basics]\nname = \"Peter Gibbons\"\n":                            "toml",
+		"# My resume\nversion = 1\n":                                      "toml",
+		"[[work]]\ncompany = \"Initech\"\n":                               "toml",
+		"Peter Gibbons\n":                                                 "line",
+		`{"$schema": "https://example.com/my-resume.json", "basics": {}}`: "json",
+		`{"$schema": "https://jsonresume.org/schema", "basics": {}}`:      "jsonresume",
+		`{"$schema": "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"}`: "jsonresume",
+	}
+	for contents, name := range expected {
+		codec, err := data.DetectFormat([]byte(contents))
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %s", contents, err)
+		}
+		if codec.Name() != name {
+			t.Fatalf("Expected format \"%s\" for %q, found \"%s\"", name, contents, codec.Name())
+		}
+	}
+
+	for _, contents := range []string{"", "<html></html>", "<resumes/>", "Line one\nLine two\n"} {
+		_, err := data.DetectFormat([]byte(contents))
+		if unsupported, ok := err.(data.UnsupportedFormatError); !ok || !unsupported.Detected {
+			t.Fatalf("Expected an UnsupportedFormatError for %q, found: %v", contents, err)
+		}
+	}
+
+	// A single line of "key: value" text is valid YAML, but also matches the test "line" format
+	_, err := data.DetectFormat([]byte("name: Peter Gibbons"))
+	ambiguous, ok := err.(data.AmbiguousFormatError)
+	if !ok || !reflect.DeepEqual(ambiguous.Formats, []string{"line", "yaml"}) {
+		t.Fatalf("Expected an AmbiguousFormatError, found: %v", err)
+	}
+	if !strings.Contains(err.Error(), "line, yaml") {
+		t.Fatalf("Unexpected error message: %s", err)
+	}
+}
+
+func TestDetectFileFormat(t *testing.T) {
+	filename := filepath.Join(os.TempDir(), "testresume")
+	testutils.DeleteFileIfExists(t, filename)
+	defer testutils.DeleteFileIfExists(t, filename)
+
+	originalData := testutils.GenerateTestResumeData()
+	for _, codec := range []string{"xml", "json", "yaml", "toml"} {
+		var err error
+		switch codec {
+		case "xml":
+			err = data.ToXmlFile(originalData, filename)
+		case "json":
+			err = data.ToJsonFile(originalData, filename)
+		case "yaml":
+			err = data.ToYamlFile(originalData, filename)
+		case "toml":
+			err = data.ToTomlFile(originalData, filename)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		detected, err := data.DetectFileFormat(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if detected.Name() != codec {
+			t.Fatalf("Expected format \"%s\", found \"%s\"", codec, detected.Name())
+		}
+		fromFile, err := data.FromFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(originalData, fromFile) {
+			t.Fatalf("Resume data after detected %s conversion doesn't match the original", codec)
+		}
+	}
+}
+
+func TestFromReader(t *testing.T) {
+	originalData := testutils.GenerateTestResumeData()
+	yaml, err := data.ToYamlString(originalData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromReader, err := data.FromReader(strings.NewReader(yaml))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(originalData, fromReader) {
+		t.Fatal("Resume data read from a detected stream doesn't match the original")
+	}
+}
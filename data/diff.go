@@ -0,0 +1,320 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ChangeKind identifies the type of a "Change" found by "Diff()".
+type ChangeKind string
+
+const (
+	// ChangeAdded is a list entry or field value present only in the new resume data.
+	ChangeAdded ChangeKind = "added"
+	// ChangeRemoved is a list entry or field value present only in the old resume data.
+	ChangeRemoved ChangeKind = "removed"
+	// ChangeModified is a field whose value differs between the old and new resume data.
+	ChangeModified ChangeKind = "modified"
+	// ChangeMoved is a list entry found in both, but at a different position relative to the other entries.  Its
+	// "Old" and "New" values are the entry's zero-based index in each list.
+	ChangeMoved ChangeKind = "moved"
+)
+
+// Change is a single difference found by "Diff()".  The path uses the JSON field names, with list entries
+// identified by their key fields rather than their index (e.g. "work[Initech, Software Developer, 1998-02-01]").
+// Entries of plain string lists (such as keywords and highlights) are identified by their value, and so are only
+// ever added, removed or moved.
+type Change struct {
+	Kind ChangeKind  `json:"kind"`
+	Path string      `json:"path"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// DiffReport lists every change between two sets of resume data, in document order.
+type DiffReport struct {
+	Changes []Change `json:"changes"`
+}
+
+// Empty returns true if no changes were found.
+func (report DiffReport) Empty() bool {
+	return len(report.Changes) == 0
+}
+
+// String renders the report with one change per line, prefixed with "+" (added), "-" (removed), "~" (modified) or
+// ">" (moved).
+func (report DiffReport) String() string {
+	if report.Empty() {
+		return "No differences\n"
+	}
+	var buffer bytes.Buffer
+	for _, change := range report.Changes {
+		switch change.Kind {
+		case ChangeAdded:
+			buffer.WriteString("+ " + change.Path + diffValueSuffix(change.New))
+		case ChangeRemoved:
+			buffer.WriteString("- " + change.Path + diffValueSuffix(change.Old))
+		case ChangeModified:
+			buffer.WriteString(fmt.Sprintf("~ %s: %s -> %s", change.Path, formatDiffValue(change.Old), formatDiffValue(change.New)))
+		case ChangeMoved:
+			buffer.WriteString(fmt.Sprintf("> %s: moved from position %d to %d", change.Path, change.Old.(int)+1, change.New.(int)+1))
+		}
+		buffer.WriteString("\n")
+	}
+	return buffer.String()
+}
+
+// diffKeys returns the fields identifying an entry of each list type, so that entries can be matched regardless of
+// their position.  Entries of any other type are matched by position.
+var diffKeys = map[reflect.Type]func(value interface{}) []string{
+	reflect.TypeOf(""):          func(value interface{}) []string { return []string{value.(string)} },
+	reflect.TypeOf(Highlight{}): func(value interface{}) []string { return []string{value.(Highlight).Text} },
+	reflect.TypeOf(Work{}): func(value interface{}) []string {
+		work := value.(Work)
+		return []string{work.Company, work.Position, string(work.StartDate)}
+	},
+	reflect.TypeOf(Role{}): func(value interface{}) []string {
+		role := value.(Role)
+		return []string{role.Title, string(role.StartDate)}
+	},
+	reflect.TypeOf(WorkGroup{}): func(value interface{}) []string { return []string{value.(WorkGroup).Name} },
+	reflect.TypeOf(Volunteer{}): func(value interface{}) []string {
+		volunteer := value.(Volunteer)
+		return []string{volunteer.Organization, volunteer.Position, string(volunteer.StartDate)}
+	},
+	reflect.TypeOf(Education{}): func(value interface{}) []string {
+		education := value.(Education)
+		return []string{education.Institution, education.StudyType, education.Area}
+	},
+	reflect.TypeOf(Award{}): func(value interface{}) []string {
+		award := value.(Award)
+		return []string{award.Title, string(award.Date)}
+	},
+	reflect.TypeOf(Certificate{}): func(value interface{}) []string {
+		certificate := value.(Certificate)
+		return []string{certificate.Name, certificate.Issuer}
+	},
+	reflect.TypeOf(PublicationGroup{}): func(value interface{}) []string { return []string{value.(PublicationGroup).Name} },
+	reflect.TypeOf(Publication{}):      func(value interface{}) []string { return []string{value.(Publication).Name} },
+	reflect.TypeOf(Skill{}):            func(value interface{}) []string { return []string{value.(Skill).Name} },
+	reflect.TypeOf(Language{}):         func(value interface{}) []string { return []string{value.(Language).Language} },
+	reflect.TypeOf(Interest{}):         func(value interface{}) []string { return []string{value.(Interest).Name} },
+	reflect.TypeOf(Reference{}):        func(value interface{}) []string { return []string{value.(Reference).Name} },
+	reflect.TypeOf(Project{}):          func(value interface{}) []string { return []string{value.(Project).Name} },
+	reflect.TypeOf(SocialProfile{}): func(value interface{}) []string {
+		profile := value.(SocialProfile)
+		return []string{profile.Network, profile.Username}
+	},
+}
+
+// Diff compares two sets of resume data semantically, rather than as text.  List entries are matched by their key
+// fields (e.g. work entries by company, position and start date) rather than by position, so that inserting or
+// reordering entries doesn't show up as edits to every entry after it.  Matched entries are compared field by field,
+// and entries whose position changed relative to the others are reported as moved.  Extension fields in "Extra"
+// are compared by key, with paths just as they appear in JSON.
+func Diff(old, new ResumeData) DiffReport {
+	report := DiffReport{Changes: []Change{}}
+	diffValues(reflect.ValueOf(old), reflect.ValueOf(new), "", &report.Changes)
+	return report
+}
+
+func diffValues(old, new reflect.Value, path string, changes *[]Change) {
+	switch {
+	case old.Type() == extraType:
+		diffExtra(old.Interface().(Extra), new.Interface().(Extra), path, changes)
+	case old.Kind() == reflect.Struct:
+		for index := 0; index < old.NumField(); index++ {
+			field := old.Type().Field(index)
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if field.Type == extraType {
+				diffValues(old.Field(index), new.Field(index), path, changes)
+			} else if name != "-" && name != "" {
+				diffValues(old.Field(index), new.Field(index), diffPath(path, name), changes)
+			}
+		}
+	case old.Kind() == reflect.Slice:
+		diffLists(old, new, path, changes)
+	default:
+		if !reflect.DeepEqual(old.Interface(), new.Interface()) {
+			*changes = append(*changes, Change{Kind: ChangeModified, Path: path, Old: old.Interface(), New: new.Interface()})
+		}
+	}
+}
+
+func diffExtra(old, new Extra, path string, changes *[]Change) {
+	for _, key := range sortedKeys(old) {
+		if newValue, ok := new[key]; !ok {
+			*changes = append(*changes, Change{Kind: ChangeRemoved, Path: diffPath(path, key), Old: old[key]})
+		} else if !reflect.DeepEqual(old[key], newValue) {
+			*changes = append(*changes, Change{Kind: ChangeModified, Path: diffPath(path, key), Old: old[key], New: newValue})
+		}
+	}
+	for _, key := range sortedKeys(new) {
+		if _, ok := old[key]; !ok {
+			*changes = append(*changes, Change{Kind: ChangeAdded, Path: diffPath(path, key), New: new[key]})
+		}
+	}
+}
+
+// diffEntry is a list entry, identified by its key fields plus its occurrence among entries sharing those fields.
+type diffEntry struct {
+	key   string
+	label string
+}
+
+func diffLists(old, new reflect.Value, path string, changes *[]Change) {
+	oldEntries, newEntries := diffListEntries(old), diffListEntries(new)
+	oldIndex, newIndex := map[string]int{}, map[string]int{}
+	for index, entry := range oldEntries {
+		oldIndex[entry.key] = index
+	}
+	for index, entry := range newEntries {
+		newIndex[entry.key] = index
+	}
+	scalar := isDiffScalar(old.Type().Elem())
+
+	var oldMatched, newMatched []string
+	for index, entry := range oldEntries {
+		if _, ok := newIndex[entry.key]; !ok {
+			change := Change{Kind: ChangeRemoved, Path: path + "[" + entry.label + "]", Old: old.Index(index).Interface()}
+			if scalar {
+				change.Path = path
+			}
+			*changes = append(*changes, change)
+		} else {
+			oldMatched = append(oldMatched, entry.key)
+		}
+	}
+	for _, entry := range newEntries {
+		if _, ok := oldIndex[entry.key]; ok {
+			newMatched = append(newMatched, entry.key)
+		}
+	}
+	unmoved := longestCommonSubsequence(oldMatched, newMatched)
+
+	for index, entry := range newEntries {
+		entryPath := path + "[" + entry.label + "]"
+		oldPosition, ok := oldIndex[entry.key]
+		if !ok {
+			change := Change{Kind: ChangeAdded, Path: entryPath, New: new.Index(index).Interface()}
+			if scalar {
+				change.Path = path
+			}
+			*changes = append(*changes, change)
+			continue
+		}
+		if !unmoved[entry.key] {
+			*changes = append(*changes, Change{Kind: ChangeMoved, Path: entryPath, Old: oldPosition, New: index})
+		}
+		diffValues(old.Index(oldPosition), new.Index(index), entryPath, changes)
+	}
+}
+
+// diffListEntries identifies the entries of a list.  Entries sharing the same key fields are told apart by
+// occurrence, so that the first such entry in the old list is matched with the first in the new list, and so on.
+func diffListEntries(list reflect.Value) []diffEntry {
+	keyFunc, hasKey := diffKeys[list.Type().Elem()]
+	quote := isDiffScalar(list.Type().Elem())
+	occurrences := map[string]int{}
+	entries := make([]diffEntry, list.Len())
+	for index := range entries {
+		if !hasKey {
+			entries[index] = diffEntry{key: fmt.Sprint(index), label: fmt.Sprint(index)}
+			continue
+		}
+		var fields []string
+		for _, field := range keyFunc(list.Index(index).Interface()) {
+			if field != "" {
+				fields = append(fields, field)
+			}
+		}
+		label := strings.Join(fields, ", ")
+		if quote {
+			label = fmt.Sprintf("%q", label)
+		}
+		key := strings.Join(fields, "\x00")
+		occurrences[key]++
+		if occurrences[key] > 1 || label == "" {
+			label = fmt.Sprintf("%s#%d", label, occurrences[key])
+		}
+		entries[index] = diffEntry{key: fmt.Sprintf("%s\x00%d", key, occurrences[key]), label: label}
+	}
+	return entries
+}
+
+// isDiffScalar returns true for list entry types identified by their value alone (strings and highlights), which
+// are quoted in paths and reported by value when added or removed.
+func isDiffScalar(entryType reflect.Type) bool {
+	return entryType.Kind() == reflect.String || entryType == highlightType
+}
+
+// longestCommonSubsequence returns the keys forming the longest run of entries found in the same relative order in
+// both lists.  Any other key present in both lists has moved.
+func longestCommonSubsequence(old, new []string) map[string]bool {
+	lengths := make([][]int, len(old)+1)
+	for index := range lengths {
+		lengths[index] = make([]int, len(new)+1)
+	}
+	for i := len(old) - 1; i >= 0; i-- {
+		for j := len(new) - 1; j >= 0; j-- {
+			if old[i] == new[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+	common := map[string]bool{}
+	for i, j := 0, 0; i < len(old) && j < len(new); {
+		if old[i] == new[j] {
+			common[old[i]] = true
+			i, j = i+1, j+1
+		} else if lengths[i+1][j] >= lengths[i][j+1] {
+			i++
+		} else {
+			j++
+		}
+	}
+	return common
+}
+
+func diffPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// diffValueSuffix renders an added or removed value for "DiffReport.String()".  Entries already identified by the
+// path (i.e. structs) aren't repeated.
+func diffValueSuffix(value interface{}) string {
+	switch value.(type) {
+	case string, Highlight:
+		return ": " + formatDiffValue(value)
+	}
+	if reflect.ValueOf(value).Kind() == reflect.Struct {
+		return ""
+	}
+	return ": " + formatDiffValue(value)
+}
+
+func formatDiffValue(value interface{}) string {
+	switch typed := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", typed)
+	case Date:
+		return fmt.Sprintf("%q", string(typed))
+	case Highlight:
+		return fmt.Sprintf("%q", typed.Text)
+	}
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(jsonBytes)
+}
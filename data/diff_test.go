@@ -0,0 +1,76 @@
+package data_test
+
+import (
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiff_Identical(t *testing.T) {
+	report := data.Diff(testutils.GenerateTestResumeData(), testutils.GenerateTestResumeData())
+	if !report.Empty() {
+		t.Fatalf("Expected no differences, found:\n%s", report)
+	}
+	if report.String() != "No differences\n" {
+		t.Fatalf("Unexpected report: %s", report)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old := testutils.GenerateTestResumeData()
+	new := testutils.GenerateTestResumeData()
+	new.Basics.Summary = "A new summary"
+	new.Basics.Extra["x-nickname"] = "Petey"
+	// Inserting a work entry at the front must not show up as edits to every other entry
+	new.Work = append([]data.Work{{Company: "Chotchkie's", Position: "Waiter", StartDate: "2000-01-01"}}, new.Work...)
+	new.Work[1].Website = "http://initech.example.com"
+	new.Work[1].Roles[1].Highlights = append(new.Work[1].Roles[1].Highlights, data.Highlight{Text: "Fixed the Y2K bug"})
+	new.Skills[0], new.Skills[1] = new.Skills[1], new.Skills[0]
+	new.Languages = new.Languages[:1]
+
+	report := data.Diff(old, new)
+	paths := map[string]data.Change{}
+	for _, change := range report.Changes {
+		paths[string(change.Kind)+" "+change.Path] = change
+	}
+	expected := []string{
+		"modified basics.summary",
+		"modified basics.x-nickname",
+		"added work[Chotchkie's, Waiter, 2000-01-01]",
+		"modified work[Initech, Software Developer, 1998-02-01].website",
+		"added work[Initech, Software Developer, 1998-02-01].roles[Senior Software Developer, 1999-07-01].highlights",
+		"moved skills[Programming]",
+		"removed languages[Spanish]",
+	}
+	for _, key := range expected {
+		if _, ok := paths[key]; !ok {
+			t.Fatalf("Expected change \"%s\", found:\n%s", key, report)
+		}
+	}
+	if len(report.Changes) != len(expected) {
+		t.Fatalf("Expected %d changes, found:\n%s", len(expected), report)
+	}
+
+	moved := paths["moved skills[Programming]"]
+	if moved.Old != 0 || moved.New != 1 {
+		t.Fatalf("Unexpected move: %+v", moved)
+	}
+	text := report.String()
+	if !strings.Contains(text, "~ basics.summary: \"Just a straight-shooter with upper managment written all over him\" -> \"A new summary\"\n") ||
+		!strings.Contains(text, "+ work[Initech, Software Developer, 1998-02-01].roles[Senior Software Developer, 1999-07-01].highlights: \"Fixed the Y2K bug\"\n") ||
+		!strings.Contains(text, "> skills[Programming]: moved from position 1 to 2\n") {
+		t.Fatalf("Unexpected text report:\n%s", text)
+	}
+}
+
+func TestDiff_DuplicateKeys(t *testing.T) {
+	old := data.ResumeData{Basics: data.Basics{Highlights: []string{"a", "b", "a"}}}
+	new := data.ResumeData{Basics: data.Basics{Highlights: []string{"a", "b"}}}
+	report := data.Diff(old, new)
+	expected := []data.Change{{Kind: data.ChangeRemoved, Path: "basics.highlights", Old: "a"}}
+	if !reflect.DeepEqual(report.Changes, expected) {
+		t.Fatalf("Expected %+v, found %+v", expected, report.Changes)
+	}
+}
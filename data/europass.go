@@ -0,0 +1,939 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The types below model the parts of the Europass CV format (version 3) that have a counterpart in ResumeData.  They
+// carry JSON tags only, matching the Europass JSON format.  The Europass XML format has the same structure, except
+// that lists are wrapped in a "...List" element and dates are written as attributes, and so XML is converted to and
+// from the JSON structure (see "europassXmlElement()" and "writeEuropassXml()").
+
+type europassDocument struct {
+	SkillsPassport europassPassport `json:"SkillsPassport"`
+}
+
+type europassPassport struct {
+	Locale       string               `json:"Locale,omitempty"`
+	DocumentInfo *europassDocInfo     `json:"DocumentInfo,omitempty"`
+	LearnerInfo  *europassLearnerInfo `json:"LearnerInfo,omitempty"`
+}
+
+type europassDocInfo struct {
+	DocumentType string `json:"DocumentType,omitempty"`
+}
+
+type europassLearnerInfo struct {
+	Identification *europassIdentification `json:"Identification,omitempty"`
+	Headline       *europassHeadline       `json:"Headline,omitempty"`
+	WorkExperience []europassExperience    `json:"WorkExperience,omitempty"`
+	Education      []europassExperience    `json:"Education,omitempty"`
+	Skills         *europassSkills         `json:"Skills,omitempty"`
+}
+
+type europassIdentification struct {
+	PersonName  *europassPersonName  `json:"PersonName,omitempty"`
+	ContactInfo *europassContactInfo `json:"ContactInfo,omitempty"`
+}
+
+type europassPersonName struct {
+	FirstName string `json:"FirstName,omitempty"`
+	Surname   string `json:"Surname,omitempty"`
+}
+
+type europassContactInfo struct {
+	Address          *europassAddress  `json:"Address,omitempty"`
+	Email            *europassContact  `json:"Email,omitempty"`
+	Telephone        []europassContact `json:"Telephone,omitempty"`
+	Website          []europassContact `json:"Website,omitempty"`
+	InstantMessaging []europassContact `json:"InstantMessaging,omitempty"`
+}
+
+type europassAddress struct {
+	Contact *europassAddressContact `json:"Contact,omitempty"`
+}
+
+type europassAddressContact struct {
+	AddressLine  string         `json:"AddressLine,omitempty"`
+	PostalCode   string         `json:"PostalCode,omitempty"`
+	Municipality string         `json:"Municipality,omitempty"`
+	Country      *europassLabel `json:"Country,omitempty"`
+}
+
+type europassContact struct {
+	Contact string         `json:"Contact,omitempty"`
+	Use     *europassLabel `json:"Use,omitempty"`
+}
+
+type europassLabel struct {
+	Code  string `json:"Code,omitempty"`
+	Label string `json:"Label,omitempty"`
+}
+
+type europassHeadline struct {
+	Type        *europassLabel `json:"Type,omitempty"`
+	Description *europassLabel `json:"Description,omitempty"`
+}
+
+// europassExperience is used for both work experience and education, which share most of their structure.
+type europassExperience struct {
+	Period       *europassPeriod       `json:"Period,omitempty"`
+	Position     *europassLabel        `json:"Position,omitempty"`
+	Title        string                `json:"Title,omitempty"`
+	Activities   string                `json:"Activities,omitempty"`
+	Employer     *europassOrganisation `json:"Employer,omitempty"`
+	Organisation *europassOrganisation `json:"Organisation,omitempty"`
+	Field        *europassLabel        `json:"Field,omitempty"`
+}
+
+type europassPeriod struct {
+	From    *europassDate `json:"From,omitempty"`
+	To      *europassDate `json:"To,omitempty"`
+	Current bool          `json:"Current,omitempty"`
+}
+
+type europassDate struct {
+	Year  int `json:"Year,omitempty"`
+	Month int `json:"Month,omitempty"`
+	Day   int `json:"Day,omitempty"`
+}
+
+type europassOrganisation struct {
+	Name        string               `json:"Name,omitempty"`
+	ContactInfo *europassContactInfo `json:"ContactInfo,omitempty"`
+}
+
+type europassSkills struct {
+	Linguistic     *europassLinguistic `json:"Linguistic,omitempty"`
+	Communication  *europassSkill      `json:"Communication,omitempty"`
+	Organisational *europassSkill      `json:"Organisational,omitempty"`
+	JobRelated     *europassSkill      `json:"JobRelated,omitempty"`
+	Computer       *europassSkill      `json:"Computer,omitempty"`
+	Other          *europassSkill      `json:"Other,omitempty"`
+}
+
+type europassSkill struct {
+	Description string `json:"Description,omitempty"`
+}
+
+type europassLinguistic struct {
+	MotherTongue    []europassLanguage `json:"MotherTongue,omitempty"`
+	ForeignLanguage []europassLanguage `json:"ForeignLanguage,omitempty"`
+}
+
+type europassLanguage struct {
+	Description      *europassLabel       `json:"Description,omitempty"`
+	ProficiencyLevel *europassProficiency `json:"ProficiencyLevel,omitempty"`
+}
+
+type europassProficiency struct {
+	Listening         string `json:"Listening,omitempty"`
+	Reading           string `json:"Reading,omitempty"`
+	SpokenInteraction string `json:"SpokenInteraction,omitempty"`
+	SpokenProduction  string `json:"SpokenProduction,omitempty"`
+	Writing           string `json:"Writing,omitempty"`
+}
+
+var europassDocumentType = reflect.TypeOf(europassDocument{})
+
+// europassIgnored lists parts of a Europass document that describe the document itself rather than the candidate,
+// and so aren't reported as unmapped when importing.
+var europassIgnored = map[string]bool{
+	"SkillsPassport.DocumentInfo":        true,
+	"SkillsPassport.PrintingPreferences": true,
+}
+
+// europassNamespace is the XML namespace of Europass CV documents.
+const europassNamespace = "http://europass.cedefop.europa.eu/Europass"
+
+// cefrLevels are the language proficiency levels used by Europass (the Common European Framework of Reference).
+var cefrLevels = map[string]bool{"A1": true, "A2": true, "B1": true, "B2": true, "C1": true, "C2": true}
+
+// FromEuropassJson loads resume data from a Europass CV in JSON format.  The returned report lists every part of the
+// Europass document that has no counterpart in ResumeData (e.g. "SkillsPassport.LearnerInfo.Identification.Photo").
+func FromEuropassJson(reader io.Reader) (ResumeData, MappingReport, error) {
+	var document interface{}
+	if err := json.NewDecoder(reader).Decode(&document); err != nil {
+		return ResumeData{}, MappingReport{}, err
+	}
+	return fromEuropassGeneric(document)
+}
+
+// FromEuropassXml loads resume data from a Europass CV in XML format.  See "FromEuropassJson()".
+func FromEuropassXml(reader io.Reader) (ResumeData, MappingReport, error) {
+	decoder := xml.NewDecoder(reader)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return ResumeData{}, MappingReport{}, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			if start.Name.Local != "SkillsPassport" {
+				return ResumeData{}, MappingReport{}, fmt.Errorf("Expected a Europass \"SkillsPassport\" element, found \"%s\"", start.Name.Local)
+			}
+			root, err := europassXmlElement(decoder, start)
+			if err != nil {
+				return ResumeData{}, MappingReport{}, err
+			}
+			return fromEuropassGeneric(map[string]interface{}{"SkillsPassport": root})
+		}
+	}
+}
+
+// ToEuropassJson writes resume data as a Europass CV in JSON format.  The returned report lists every non-empty
+// field of the resume data that Europass can't represent (e.g. "basics: picture").
+func ToEuropassJson(data ResumeData, writer io.Writer) (MappingReport, error) {
+	document, report := toEuropass(data)
+	jsonBytes, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return report, err
+	}
+	_, err = writer.Write(jsonBytes)
+	return report, err
+}
+
+// ToEuropassXml writes resume data as a Europass CV in XML format.  See "ToEuropassJson()".
+func ToEuropassXml(data ResumeData, writer io.Writer) (MappingReport, error) {
+	document, report := toEuropass(data)
+	jsonBytes, err := json.Marshal(document.SkillsPassport)
+	if err != nil {
+		return report, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+	ordered, err := decodeOrdered(decoder)
+	if err != nil {
+		return report, err
+	}
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return report, err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err := writeEuropassXml(encoder, "SkillsPassport", ordered, true); err != nil {
+		return report, err
+	}
+	return report, encoder.Flush()
+}
+
+func fromEuropassGeneric(document interface{}) (ResumeData, MappingReport, error) {
+	report := MappingReport{}
+	reportUnmappedGeneric(document, europassDocumentType, "", "Europass", europassIgnored, &report)
+	jsonBytes, err := json.Marshal(coerceGeneric(document, europassDocumentType))
+	if err != nil {
+		return ResumeData{}, report, err
+	}
+	var europass europassDocument
+	if err := json.Unmarshal(jsonBytes, &europass); err != nil {
+		return ResumeData{}, report, err
+	}
+	return fromEuropass(europass.SkillsPassport, &report), report, nil
+}
+
+// reportUnmappedGeneric walks a generic value alongside a struct type, reporting every non-empty property that has
+// no matching struct field.  Paths within the ignored set are skipped.
+func reportUnmappedGeneric(value interface{}, valueType reflect.Type, path, source string, ignored map[string]bool, report *MappingReport) {
+	for valueType.Kind() == reflect.Ptr || valueType.Kind() == reflect.Slice {
+		valueType = valueType.Elem()
+	}
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(typed) {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			if ignored[fieldPath] || isEmptyGeneric(typed[key]) {
+				continue
+			}
+			if valueType.Kind() != reflect.Struct {
+				report.addUnmapped(source, fieldPath)
+				continue
+			}
+			field, ok := structField(valueType, "json", key)
+			if !ok {
+				report.addUnmapped(source, fieldPath)
+				continue
+			}
+			reportUnmappedGeneric(typed[key], field.Type, fieldPath, source, ignored, report)
+		}
+	case []interface{}:
+		for _, item := range typed {
+			reportUnmappedGeneric(item, valueType, path, source, ignored, report)
+		}
+	}
+}
+
+// isEmptyGeneric returns true for nil, blank text, and lists or maps containing only empty values.
+func isEmptyGeneric(value interface{}) bool {
+	switch typed := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(typed) == ""
+	case []interface{}:
+		for _, item := range typed {
+			if !isEmptyGeneric(item) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		for _, item := range typed {
+			if !isEmptyGeneric(item) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// europassXmlElement reads the content of a Europass XML element, whose start tag has already been read, into the
+// structure of the Europass JSON format.  Elements containing only text become strings, and other elements become
+// maps.  The children of a "...List" element are collected into a list, keyed by the name without the suffix.
+// Attributes become capitalized keys, with XML Schema month and day values (e.g. "--02" and "---01") converted to
+// plain numbers.
+func europassXmlElement(decoder *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	element := map[string]interface{}{}
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" || attr.Name.Space != "" {
+			continue
+		}
+		key := strings.ToUpper(attr.Name.Local[:1]) + attr.Name.Local[1:]
+		element[key] = strings.TrimLeft(attr.Value, "-")
+	}
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch typed := token.(type) {
+		case xml.CharData:
+			text.Write(typed)
+		case xml.StartElement:
+			name := typed.Name.Local
+			if strings.HasSuffix(name, "List") && len(name) > len("List") {
+				list, err := europassXmlList(decoder)
+				if err != nil {
+					return nil, err
+				}
+				element[strings.TrimSuffix(name, "List")] = list
+				continue
+			}
+			child, err := europassXmlElement(decoder, typed)
+			if err != nil {
+				return nil, err
+			}
+			element[name] = child
+		case xml.EndElement:
+			if len(element) == 0 {
+				return strings.TrimSpace(text.String()), nil
+			}
+			return element, nil
+		}
+	}
+}
+
+// europassXmlList reads the child elements of a "...List" element, whose start tag has already been read.
+func europassXmlList(decoder *xml.Decoder) ([]interface{}, error) {
+	list := []interface{}{}
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch typed := token.(type) {
+		case xml.StartElement:
+			item, err := europassXmlElement(decoder, typed)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		case xml.EndElement:
+			return list, nil
+		}
+	}
+}
+
+// europassAttributes maps the keys that are written as XML attributes, rather than child elements, to their
+// attribute names.
+var europassAttributes = map[string]string{"Locale": "locale", "Year": "year", "Month": "month", "Day": "day"}
+
+// writeEuropassXml writes a value from the Europass JSON structure (as decoded by "decodeOrdered()") as an XML
+// element.  This reverses the conversion made by "europassXmlElement()".
+func writeEuropassXml(encoder *xml.Encoder, name string, value interface{}, root bool) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if root {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: europassNamespace})
+	}
+	object, isObject := value.(yaml.MapSlice)
+	var children yaml.MapSlice
+	for _, item := range object {
+		key := fmt.Sprint(item.Key)
+		if attrName, ok := europassAttributes[key]; ok {
+			attrValue := fmt.Sprint(item.Value)
+			if number, err := strconv.Atoi(attrValue); err == nil && key == "Month" {
+				attrValue = fmt.Sprintf("--%02d", number)
+			} else if err == nil && key == "Day" {
+				attrValue = fmt.Sprintf("---%02d", number)
+			}
+			start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: attrName}, Value: attrValue})
+		} else {
+			children = append(children, item)
+		}
+	}
+	if err := encoder.EncodeToken(start); err != nil {
+		return err
+	}
+	if !isObject {
+		if err := encoder.EncodeToken(xml.CharData(fmt.Sprint(value))); err != nil {
+			return err
+		}
+	}
+	for _, item := range children {
+		key := fmt.Sprint(item.Key)
+		list, isList := item.Value.([]interface{})
+		if !isList {
+			if err := writeEuropassXml(encoder, key, item.Value, false); err != nil {
+				return err
+			}
+			continue
+		}
+		listStart := xml.StartElement{Name: xml.Name{Local: key + "List"}}
+		if err := encoder.EncodeToken(listStart); err != nil {
+			return err
+		}
+		for _, listItem := range list {
+			if err := writeEuropassXml(encoder, key, listItem, false); err != nil {
+				return err
+			}
+		}
+		if err := encoder.EncodeToken(listStart.End()); err != nil {
+			return err
+		}
+	}
+	return encoder.EncodeToken(start.End())
+}
+
+// toEuropass maps resume data onto the Europass structure, reporting any non-empty fields that are left behind.
+func toEuropass(data ResumeData) (europassDocument, MappingReport) {
+	report := MappingReport{}
+	unmapped := func(source, field string, value interface{}) {
+		if !isEmptyValue(reflect.ValueOf(value)) {
+			report.addUnmapped(source, field)
+		}
+	}
+	learner := &europassLearnerInfo{}
+
+	// Basics
+	basics := data.Basics
+	identification := &europassIdentification{ContactInfo: &europassContactInfo{}}
+	if names := strings.Fields(basics.Name); len(names) > 0 {
+		identification.PersonName = &europassPersonName{
+			FirstName: strings.Join(names[:len(names)-1], " "),
+			Surname:   names[len(names)-1],
+		}
+	}
+	contact := identification.ContactInfo
+	location := basics.Location
+	if location.Address != "" || location.PostalCode != "" || location.City != "" || location.CountryCode != "" {
+		address := &europassAddressContact{
+			AddressLine:  location.Address,
+			PostalCode:   location.PostalCode,
+			Municipality: location.City,
+		}
+		if location.CountryCode != "" {
+			address.Country = &europassLabel{Code: location.CountryCode}
+		}
+		contact.Address = &europassAddress{Contact: address}
+	}
+	if basics.Email != "" {
+		contact.Email = &europassContact{Contact: basics.Email}
+	}
+	if basics.Phone != "" {
+		contact.Telephone = []europassContact{{Contact: basics.Phone}}
+	}
+	if basics.Website != "" {
+		contact.Website = []europassContact{{Contact: basics.Website, Use: &europassLabel{Code: "personal"}}}
+	}
+	for _, profile := range basics.Profiles {
+		if profile.Url != "" {
+			contact.Website = append(contact.Website,
+				europassContact{Contact: profile.Url, Use: &europassLabel{Label: profile.Network}})
+		} else {
+			contact.InstantMessaging = append(contact.InstantMessaging,
+				europassContact{Contact: profile.Username, Use: &europassLabel{Label: profile.Network}})
+		}
+	}
+	learner.Identification = identification
+	if basics.Label != "" {
+		learner.Headline = &europassHeadline{
+			Type:        &europassLabel{Code: "position", Label: "Desired employment / Occupational field"},
+			Description: &europassLabel{Label: basics.Label},
+		}
+	}
+	unmapped("basics", "picture", basics.Picture)
+	unmapped("basics", "degree", basics.Degree)
+	unmapped("basics", "summary", basics.Summary)
+	unmapped("basics", "highlights", basics.Highlights)
+	unmapped("basics", "location.region", location.Region)
+	for _, key := range sortedKeys(basics.Extra) {
+		unmapped("basics", key, basics.Extra[key])
+	}
+
+	// Work, with each role at an employer written as a separate experience
+	for _, group := range data.AllWorkGroups() {
+		for _, work := range group.Work {
+			for _, role := range work.AllRoles() {
+				summary := role.Summary
+				if len(work.Roles) > 0 && work.Summary != "" {
+					summary = strings.TrimSpace(work.Summary + "\n" + summary)
+				}
+				experience := europassExperience{
+					Period:     europassPeriodFrom(role.StartDate, role.EndDate, &report),
+					Position:   &europassLabel{Label: role.Title},
+					Activities: joinBullets(summary, highlightTexts(role.Highlights)),
+					Employer:   &europassOrganisation{Name: work.Company},
+				}
+				if work.Website != "" {
+					experience.Employer.ContactInfo = &europassContactInfo{Website: []europassContact{{Contact: work.Website}}}
+				}
+				learner.WorkExperience = append(learner.WorkExperience, experience)
+			}
+			if len(work.Roles) > 0 {
+				unmapped("work", "highlights", work.Highlights)
+			}
+			unmapped("work", "tags", work.Tags)
+			for _, key := range sortedKeys(work.Extra) {
+				unmapped("work", key, work.Extra[key])
+			}
+		}
+	}
+	unmapped("workLabel", "workLabel", data.WorkLabel)
+	unmapped("additionalWorkLabel", "additionalWorkLabel", data.AdditionalWorkLabel)
+	for _, group := range data.WorkGroups {
+		unmapped("workGroups", "name", group.Name)
+	}
+
+	// Education
+	for _, education := range data.Education {
+		experience := europassExperience{
+			Period:       europassPeriodFrom(education.StartDate, education.EndDate, &report),
+			Title:        education.StudyType,
+			Activities:   joinBullets("", education.Courses),
+			Organisation: &europassOrganisation{Name: education.Institution},
+		}
+		if education.Area != "" {
+			experience.Field = &europassLabel{Label: education.Area}
+		}
+		learner.Education = append(learner.Education, experience)
+		unmapped("education", "gpa", education.GPA)
+		unmapped("education", "tags", education.Tags)
+	}
+
+	// Skills, listed one per line as job-related skills, and languages
+	skills := &europassSkills{}
+	var skillLines []string
+	for _, skill := range data.Skills {
+		skillLines = append(skillLines, europassSkillLine(skill))
+		unmapped("skills", "tags", skill.Tags)
+	}
+	if len(skillLines) > 0 {
+		skills.JobRelated = &europassSkill{Description: strings.Join(skillLines, "\n")}
+	}
+	for _, language := range data.Languages {
+		description := &europassLabel{Label: language.Language}
+		fluency := strings.ToUpper(strings.TrimSpace(language.Fluency))
+		if skills.Linguistic == nil {
+			skills.Linguistic = &europassLinguistic{}
+		}
+		if strings.Contains(strings.ToLower(language.Fluency), "native") || strings.EqualFold(language.Fluency, "mother tongue") {
+			skills.Linguistic.MotherTongue = append(skills.Linguistic.MotherTongue, europassLanguage{Description: description})
+			continue
+		}
+		foreign := europassLanguage{Description: description}
+		if cefrLevels[fluency] {
+			foreign.ProficiencyLevel = &europassProficiency{fluency, fluency, fluency, fluency, fluency}
+		} else {
+			unmapped("languages", "fluency", language.Fluency)
+		}
+		skills.Linguistic.ForeignLanguage = append(skills.Linguistic.ForeignLanguage, foreign)
+	}
+	if skills.Linguistic != nil || skills.JobRelated != nil {
+		learner.Skills = skills
+	}
+
+	// Sections that Europass has no place for
+	unmapped("volunteer", "volunteer", data.Volunteer)
+	unmapped("awards", "awards", data.Awards)
+	unmapped("certificates", "certificates", data.Certificates)
+	for _, group := range data.AllPublicationGroups() {
+		unmapped("publications", "publications", group.Publications)
+	}
+	unmapped("interests", "interests", data.Interests)
+	unmapped("references", "references", data.References)
+	unmapped("projects", "projects", data.Projects)
+	for _, key := range sortedKeys(data.Extra) {
+		unmapped("resume", key, data.Extra[key])
+	}
+
+	passport := europassPassport{
+		Locale:       "en",
+		DocumentInfo: &europassDocInfo{DocumentType: "ECV"},
+		LearnerInfo:  learner,
+	}
+	return europassDocument{SkillsPassport: passport}, report
+}
+
+// fromEuropass maps the Europass structure onto resume data.
+func fromEuropass(passport europassPassport, report *MappingReport) ResumeData {
+	data := ResumeData{Version: SCHEMA_VERSION}
+	learner := passport.LearnerInfo
+	if learner == nil {
+		return data
+	}
+
+	if identification := learner.Identification; identification != nil {
+		if name := identification.PersonName; name != nil {
+			data.Basics.Name = strings.TrimSpace(name.FirstName + " " + name.Surname)
+		}
+		if contact := identification.ContactInfo; contact != nil {
+			if contact.Address != nil && contact.Address.Contact != nil {
+				address := contact.Address.Contact
+				data.Basics.Location.Address = address.AddressLine
+				data.Basics.Location.PostalCode = address.PostalCode
+				data.Basics.Location.City = address.Municipality
+				if address.Country != nil {
+					data.Basics.Location.CountryCode = address.Country.Code
+				}
+			}
+			if contact.Email != nil {
+				data.Basics.Email = contact.Email.Contact
+			}
+			if len(contact.Telephone) > 0 {
+				data.Basics.Phone = contact.Telephone[0].Contact
+				for _, telephone := range contact.Telephone[1:] {
+					report.Notes = append(report.Notes, fmt.Sprintf("Europass: additional telephone number \"%s\" was left out", telephone.Contact))
+				}
+			}
+			for _, website := range contact.Website {
+				if data.Basics.Website == "" && (website.Use == nil || website.Use.Label == "") {
+					data.Basics.Website = website.Contact
+				} else {
+					data.Basics.Profiles = append(data.Basics.Profiles, SocialProfile{Network: europassUse(website.Use), Url: website.Contact})
+				}
+			}
+			for _, messaging := range contact.InstantMessaging {
+				data.Basics.Profiles = append(data.Basics.Profiles, SocialProfile{Network: europassUse(messaging.Use), Username: messaging.Contact})
+			}
+		}
+	}
+	if learner.Headline != nil && learner.Headline.Description != nil {
+		data.Basics.Label = learner.Headline.Description.Label
+	}
+
+	for _, experience := range learner.WorkExperience {
+		start, end := europassPeriodDates(experience.Period)
+		summary, highlights := splitBullets(experience.Activities)
+		work := Work{StartDate: start, EndDate: end, Summary: summary}
+		for _, highlight := range highlights {
+			work.Highlights = append(work.Highlights, Highlight{Text: highlight})
+		}
+		if experience.Position != nil {
+			work.Position = experience.Position.Label
+		}
+		if employer := experience.Employer; employer != nil {
+			work.Company = employer.Name
+			if employer.ContactInfo != nil && len(employer.ContactInfo.Website) > 0 {
+				work.Website = employer.ContactInfo.Website[0].Contact
+			}
+		}
+		data.Work = append(data.Work, work)
+	}
+
+	for _, experience := range learner.Education {
+		start, end := europassPeriodDates(experience.Period)
+		summary, courses := splitBullets(experience.Activities)
+		education := Education{StudyType: experience.Title, StartDate: start, EndDate: end, Courses: courses}
+		if summary != "" {
+			report.Notes = append(report.Notes, fmt.Sprintf("Europass: education activities \"%s\" were left out", summary))
+		}
+		if experience.Organisation != nil {
+			education.Institution = experience.Organisation.Name
+		}
+		if experience.Field != nil {
+			education.Area = experience.Field.Label
+		}
+		data.Education = append(data.Education, education)
+	}
+
+	if skills := learner.Skills; skills != nil {
+		categories := []struct {
+			name  string
+			skill *europassSkill
+		}{
+			{"Communication", skills.Communication},
+			{"Organisational", skills.Organisational},
+			{"Job-related", skills.JobRelated},
+			{"Computer", skills.Computer},
+			{"Other", skills.Other},
+		}
+		for _, category := range categories {
+			if category.skill != nil {
+				data.Skills = append(data.Skills, parseEuropassSkills(category.name, category.skill.Description)...)
+			}
+		}
+		if linguistic := skills.Linguistic; linguistic != nil {
+			for _, language := range linguistic.MotherTongue {
+				data.Languages = append(data.Languages, Language{Language: europassLabelText(language.Description), Fluency: "Native speaker"})
+			}
+			for _, language := range linguistic.ForeignLanguage {
+				data.Languages = append(data.Languages, Language{
+					Language: europassLabelText(language.Description),
+					Fluency:  europassFluency(language.ProficiencyLevel),
+				})
+			}
+		}
+	}
+	return data
+}
+
+// europassPeriodFrom converts a date range, noting any dates that Europass can't represent.
+func europassPeriodFrom(start, end Date, report *MappingReport) *europassPeriod {
+	period := &europassPeriod{From: europassDateFrom(start, report), To: europassDateFrom(end, report)}
+	if end.IsPresent() || (end.IsBlank() && !start.IsBlank()) {
+		period.Current = true
+	}
+	if period.From == nil && period.To == nil && !period.Current {
+		return nil
+	}
+	return period
+}
+
+func europassDateFrom(date Date, report *MappingReport) *europassDate {
+	parsed, ok := date.Time()
+	if !ok {
+		if !date.IsBlank() && !date.IsPresent() {
+			report.Notes = append(report.Notes, fmt.Sprintf("Europass: unrecognized date \"%s\" was left out", date))
+		}
+		return nil
+	}
+	converted := &europassDate{Year: parsed.Year()}
+	if date.Precision() >= PrecisionMonth {
+		converted.Month = int(parsed.Month())
+	}
+	if date.Precision() >= PrecisionDay {
+		converted.Day = parsed.Day()
+	}
+	return converted
+}
+
+// europassPeriodDates converts a Europass period into a date range.  A current period ends at "present".
+func europassPeriodDates(period *europassPeriod) (Date, Date) {
+	if period == nil {
+		return "", ""
+	}
+	end := europassDateText(period.To)
+	if period.Current {
+		end = Present
+	}
+	return europassDateText(period.From), end
+}
+
+func europassDateText(date *europassDate) Date {
+	if date == nil || date.Year == 0 {
+		return ""
+	} else if date.Month == 0 {
+		return Date(fmt.Sprintf("%04d", date.Year))
+	} else if date.Day == 0 {
+		return Date(fmt.Sprintf("%04d-%02d", date.Year, date.Month))
+	}
+	return Date(fmt.Sprintf("%04d-%02d-%02d", date.Year, date.Month, date.Day))
+}
+
+// europassSkillLine writes a skill as a single line of text, e.g. "- Go (Expert): goroutines, channels".
+func europassSkillLine(skill Skill) string {
+	line := "- " + skill.Name
+	if skill.Level != "" {
+		line += " (" + skill.Level + ")"
+	}
+	if len(skill.Keywords) > 0 {
+		line += ": " + strings.Join(skill.Keywords, ", ")
+	}
+	return line
+}
+
+// parseEuropassSkills reads the skills from a Europass skill description.  Lines written by "europassSkillLine()"
+// are read back as individual skills, and any other text becomes a single skill named after its category.
+func parseEuropassSkills(category, description string) []Skill {
+	var skills []Skill
+	var other []string
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "- ") {
+			if line != "" {
+				other = append(other, line)
+			}
+			continue
+		}
+		skill := Skill{}
+		line = strings.TrimSpace(line[2:])
+		if index := strings.Index(line, ": "); index >= 0 {
+			for _, keyword := range strings.Split(line[index+2:], ",") {
+				skill.Keywords = append(skill.Keywords, strings.TrimSpace(keyword))
+			}
+			line = line[:index]
+		}
+		if strings.HasSuffix(line, ")") {
+			if index := strings.LastIndex(line, " ("); index >= 0 {
+				skill.Level = line[index+2 : len(line)-1]
+				line = line[:index]
+			}
+		}
+		skill.Name = line
+		skills = append(skills, skill)
+	}
+	if len(other) > 0 {
+		skills = append(skills, Skill{Name: category, Keywords: other})
+	}
+	return skills
+}
+
+func europassUse(use *europassLabel) string {
+	if use == nil {
+		return ""
+	} else if use.Label != "" {
+		return use.Label
+	}
+	return use.Code
+}
+
+func europassLabelText(label *europassLabel) string {
+	if label == nil {
+		return ""
+	} else if label.Label != "" {
+		return label.Label
+	}
+	return label.Code
+}
+
+// europassFluency describes a Europass language proficiency.  A single CEFR level is used if every skill is at the
+// same level, and otherwise each skill is listed.
+func europassFluency(level *europassProficiency) string {
+	if level == nil {
+		return ""
+	}
+	levels := []struct{ name, value string }{
+		{"Listening", level.Listening},
+		{"Reading", level.Reading},
+		{"Spoken interaction", level.SpokenInteraction},
+		{"Spoken production", level.SpokenProduction},
+		{"Writing", level.Writing},
+	}
+	same := true
+	var parts []string
+	for _, entry := range levels {
+		if entry.value != levels[0].value {
+			same = false
+		}
+		if entry.value != "" {
+			parts = append(parts, entry.name+" "+entry.value)
+		}
+	}
+	if same {
+		return levels[0].value
+	}
+	return strings.Join(parts, ", ")
+}
+
+func init() {
+	RegisterCodec(europassXmlCodec{})
+	RegisterCodec(europassJsonCodec{})
+	foreignRoots["SkillsPassport"] = true
+}
+
+// europassXmlCodec is the Europass CV format in XML.  Files are recognized by the ".europass.xml" extension, or by a
+// "SkillsPassport" root element.
+type europassXmlCodec struct{}
+
+func (europassXmlCodec) Name() string {
+	return "europass-xml"
+}
+
+func (europassXmlCodec) Extensions() []string {
+	return []string{".europass.xml"}
+}
+
+func (europassXmlCodec) MimeType() string {
+	return "application/xml"
+}
+
+func (codec europassXmlCodec) Decode(reader io.Reader) (ResumeData, error) {
+	data, _, err := codec.DecodeWithReport(reader)
+	return data, err
+}
+
+func (codec europassXmlCodec) Encode(data ResumeData, writer io.Writer) error {
+	_, err := codec.EncodeWithReport(data, writer)
+	return err
+}
+
+func (europassXmlCodec) DecodeWithReport(reader io.Reader) (ResumeData, MappingReport, error) {
+	return FromEuropassXml(reader)
+}
+
+func (europassXmlCodec) EncodeWithReport(data ResumeData, writer io.Writer) (MappingReport, error) {
+	return ToEuropassXml(data, writer)
+}
+
+func (europassXmlCodec) Detect(head []byte) bool {
+	return xmlRoot(head) == "SkillsPassport"
+}
+
+// europassJsonCodec is the Europass CV format in JSON.  Files are recognized by the ".europass.json" extension, or
+// by a "SkillsPassport" property at the start of the top-level object.
+type europassJsonCodec struct{}
+
+func (europassJsonCodec) Name() string {
+	return "europass-json"
+}
+
+func (europassJsonCodec) Extensions() []string {
+	return []string{".europass.json"}
+}
+
+func (europassJsonCodec) MimeType() string {
+	return "application/json"
+}
+
+func (codec europassJsonCodec) Decode(reader io.Reader) (ResumeData, error) {
+	data, _, err := codec.DecodeWithReport(reader)
+	return data, err
+}
+
+func (codec europassJsonCodec) Encode(data ResumeData, writer io.Writer) error {
+	_, err := codec.EncodeWithReport(data, writer)
+	return err
+}
+
+func (europassJsonCodec) DecodeWithReport(reader io.Reader) (ResumeData, MappingReport, error) {
+	return FromEuropassJson(reader)
+}
+
+func (europassJsonCodec) EncodeWithReport(data ResumeData, writer io.Writer) (MappingReport, error) {
+	return ToEuropassJson(data, writer)
+}
+
+func (europassJsonCodec) Detect(head []byte) bool {
+	return jsonFirstKey(head) == "SkillsPassport"
+}
@@ -0,0 +1,191 @@
+package data_test
+
+import (
+	"bytes"
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEuropassConversion(t *testing.T) {
+	originalData := testutils.GenerateTestResumeData()
+	formats := []struct {
+		name   string
+		export func(data.ResumeData, *bytes.Buffer) (data.MappingReport, error)
+		load   func(*bytes.Buffer) (data.ResumeData, data.MappingReport, error)
+	}{
+		{
+			"XML",
+			func(resume data.ResumeData, buffer *bytes.Buffer) (data.MappingReport, error) {
+				return data.ToEuropassXml(resume, buffer)
+			},
+			func(buffer *bytes.Buffer) (data.ResumeData, data.MappingReport, error) {
+				return data.FromEuropassXml(buffer)
+			},
+		},
+		{
+			"JSON",
+			func(resume data.ResumeData, buffer *bytes.Buffer) (data.MappingReport, error) {
+				return data.ToEuropassJson(resume, buffer)
+			},
+			func(buffer *bytes.Buffer) (data.ResumeData, data.MappingReport, error) {
+				return data.FromEuropassJson(buffer)
+			},
+		},
+	}
+	for _, format := range formats {
+		var buffer bytes.Buffer
+		exportReport, err := format.export(originalData, &buffer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{"basics: summary", "basics: highlights", "basics: x-nickname",
+			"basics: location.region", "work: tags", "languages: fluency", "volunteer: volunteer", "awards: awards"} {
+			if !hasUnmapped(exportReport, expected) {
+				t.Fatalf("%s export report is missing \"%s\": %v", format.name, expected, exportReport.Unmapped)
+			}
+		}
+
+		resume, importReport, err := format.load(&buffer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(importReport.Unmapped) > 0 {
+			t.Fatalf("Unexpected %s import report: %v", format.name, importReport.Unmapped)
+		}
+		basics := resume.Basics
+		if basics.Name != "Peter Gibbons" || basics.Email != "peter.gibbons@initech.com" || basics.Phone != "555-555-5555" ||
+			basics.Location.City != "Austin" || basics.Location.PostalCode != "55555" {
+			t.Fatalf("Unexpected %s basics: %+v", format.name, basics)
+		}
+		if len(basics.Profiles) != 1 || basics.Profiles[0].Url != "http://linkedin.com/peter.gibbons" {
+			t.Fatalf("Unexpected %s profiles: %+v", format.name, basics.Profiles)
+		}
+
+		// Each role at Initech becomes a separate entry, most recent first
+		if len(resume.Work) != 4 {
+			t.Fatalf("Expected 4 %s work entries, found %d", format.name, len(resume.Work))
+		}
+		current := resume.Work[0]
+		if current.Company != "Initech" || current.Position != "Senior Software Developer" ||
+			current.StartDate != "1999-07-01" || current.EndDate != data.Present {
+			t.Fatalf("Unexpected %s current work: %+v", format.name, current)
+		}
+		flingers := resume.Work[2]
+		if flingers.EndDate != "1998-01-31" || len(flingers.Highlights) != 2 || flingers.Highlights[0].Text != "Wore 37 pieces of flair." ||
+			!strings.HasPrefix(flingers.Summary, "Paying my way") {
+			t.Fatalf("Unexpected %s work: %+v", format.name, flingers)
+		}
+		if resume.Work[3].StartDate != "1999-05" {
+			t.Fatalf("Unexpected %s month precision date: %s", format.name, resume.Work[3].StartDate)
+		}
+
+		if len(resume.Education) != 1 || resume.Education[0].Institution != "University of Austin" ||
+			resume.Education[0].Area != "B.S. Computer Science" || resume.Education[0].EndDate != "1997-12-01" {
+			t.Fatalf("Unexpected %s education: %+v", format.name, resume.Education)
+		}
+		if len(resume.Skills) != 2 || resume.Skills[0].Name != "Programming" || resume.Skills[0].Level != "Mid-level" ||
+			!reflect.DeepEqual(resume.Skills[0].Keywords, []string{"C++", "Java"}) {
+			t.Fatalf("Unexpected %s skills: %+v", format.name, resume.Skills)
+		}
+		if len(resume.Languages) != 2 || resume.Languages[0].Language != "English" || resume.Languages[0].Fluency != "Native speaker" ||
+			resume.Languages[1].Language != "Spanish" {
+			t.Fatalf("Unexpected %s languages: %+v", format.name, resume.Languages)
+		}
+	}
+}
+
+const europassSample = `<?xml version="1.0" encoding="UTF-8"?>
+<SkillsPassport xmlns="http://europass.cedefop.europa.eu/Europass" locale="en">
+  <DocumentInfo>
+    <DocumentType>ECV</DocumentType>
+    <Generator>EWA</Generator>
+  </DocumentInfo>
+  <LearnerInfo>
+    <Identification>
+      <PersonName>
+        <FirstName>Samir</FirstName>
+        <Surname>Nagheenanajar</Surname>
+      </PersonName>
+      <ContactInfo>
+        <Email><Contact>samir@initech.com</Contact></Email>
+        <TelephoneList>
+          <Telephone><Contact>555-555-1234</Contact><Use><Code>work</Code></Use></Telephone>
+        </TelephoneList>
+      </ContactInfo>
+      <Demographics><Birthdate year="1970" month="--04" day="---15"/></Demographics>
+    </Identification>
+    <WorkExperienceList>
+      <WorkExperience>
+        <Period>
+          <From year="1996" month="--02"/>
+          <Current>true</Current>
+        </Period>
+        <Position><Label>Software Developer</Label></Position>
+        <Activities>Maintained the banking software.
+- Fixed the rounding error.</Activities>
+        <Employer><Name>Initech</Name></Employer>
+      </WorkExperience>
+    </WorkExperienceList>
+    <Skills>
+      <Linguistic>
+        <ForeignLanguageList>
+          <ForeignLanguage>
+            <Description><Code>en</Code><Label>English</Label></Description>
+            <ProficiencyLevel>
+              <Listening>C1</Listening><Reading>C1</Reading><SpokenInteraction>C1</SpokenInteraction>
+              <SpokenProduction>C1</SpokenProduction><Writing>C1</Writing>
+            </ProficiencyLevel>
+          </ForeignLanguage>
+        </ForeignLanguageList>
+      </Linguistic>
+      <Computer><Description>Fluent with Unix and Windows.</Description></Computer>
+    </Skills>
+  </LearnerInfo>
+</SkillsPassport>`
+
+func TestFromEuropassXml(t *testing.T) {
+	resume, report, err := data.FromEuropassXml(strings.NewReader(europassSample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resume.Basics.Name != "Samir Nagheenanajar" || resume.Basics.Email != "samir@initech.com" || resume.Basics.Phone != "555-555-1234" {
+		t.Fatalf("Unexpected basics: %+v", resume.Basics)
+	}
+	work := resume.Work[0]
+	if work.StartDate != "1996-02" || work.EndDate != data.Present || work.Summary != "Maintained the banking software." ||
+		len(work.Highlights) != 1 || work.Highlights[0].Text != "Fixed the rounding error." {
+		t.Fatalf("Unexpected work: %+v", work)
+	}
+	if len(resume.Languages) != 1 || resume.Languages[0].Fluency != "C1" {
+		t.Fatalf("Unexpected languages: %+v", resume.Languages)
+	}
+	if len(resume.Skills) != 1 || resume.Skills[0].Name != "Computer" || resume.Skills[0].Keywords[0] != "Fluent with Unix and Windows." {
+		t.Fatalf("Unexpected skills: %+v", resume.Skills)
+	}
+	// Document information is ignored, but details about the candidate are reported
+	expected := []data.UnmappedField{{Source: "Europass", Field: "SkillsPassport.LearnerInfo.Identification.Demographics"}}
+	if !reflect.DeepEqual(report.Unmapped, expected) {
+		t.Fatalf("Unexpected report: %v", report.Unmapped)
+	}
+
+	codec, err := data.DetectFormat([]byte(europassSample))
+	if err != nil || codec.Name() != "europass-xml" {
+		t.Fatalf("Unexpected detected format: %v, %v", codec, err)
+	}
+	codec, err = data.DetectFormat([]byte(`{"SkillsPassport": {"Locale": "en"}}`))
+	if err != nil || codec.Name() != "europass-json" {
+		t.Fatalf("Unexpected detected format: %v, %v", codec, err)
+	}
+}
+
+func hasUnmapped(report data.MappingReport, field string) bool {
+	for _, unmapped := range report.Unmapped {
+		if unmapped.String() == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,292 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// ExtendsProperty is the top-level property naming the base file that a resume data file is layered on top of.  The
+// filename is relative to the directory of the file containing it.
+const ExtendsProperty = "extends"
+
+// DeleteDirective is a property that, set to true in an entry of a keyed list (see "keyedLists"), removes the
+// matching entry of the base file rather than patching it (e.g. to hide a job).
+const DeleteDirective = "$delete"
+
+// keyedLists names the lists that are merged entry by entry, rather than replaced wholesale, when an overlay is
+// applied to its base file.  Each is listed with the properties identifying an entry, which apply wherever a list
+// with that name appears (e.g. "work" within "workGroups" entries).
+var keyedLists = map[string][]string{
+	"work":                   {"company", "position", "startDate"},
+	"additionalWork":         {"company", "position", "startDate"},
+	"workGroups":             {"name"},
+	"education":              {"institution", "studyType", "area"},
+	"skills":                 {"name"},
+	"publications":           {"name"},
+	"additionalPublications": {"name"},
+	"publicationGroups":      {"name"},
+}
+
+// ExtendsCycleError is returned when a chain of "extends" references leads back to a file already in the chain.
+type ExtendsCycleError struct {
+	Chain []string
+}
+
+func (err ExtendsCycleError) Error() string {
+	return fmt.Sprintf("Resume data files extend each other in a cycle: %s", strings.Join(err.Chain, " -> "))
+}
+
+// ResolveFile loads a resume data file in any registered format, just like "FromFile()", and then applies it as an
+// overlay on top of any base file named by its "extends" property.  The base file may itself extend another, in any
+// format, and a cycle of references results in an "ExtendsCycleError".
+//
+// Overlays follow the JSON Merge Patch rules of RFC 7396:  objects are merged property by property, a null value
+// removes the property from the base, and any other value replaces it.  The exception is the lists named in
+// "keyedLists" (e.g. "work", "education", "skills" and "publications"), whose entries are matched with those of the
+// base by their key properties.  Only the key properties present in the overlay entry are compared, so that
+// {"company": "Initech", "summary": "..."} patches the Initech job.  A matched entry is merged recursively, an entry
+// with "$delete" set to true removes its match, and an unmatched entry is appended.  Every other list (such as
+// highlights) is replaced wholesale, so an overlay adding a highlight must list the existing ones too.
+//
+// Null values can only be expressed by JSON and YAML overlays, so only these can remove a property.  TOML has no
+// null, but TOML overlays are also read as written, and so can still remove keyed list entries with "$delete".
+// Overlays in other formats are read through "ResumeData", and so may only add or change values.
+func ResolveFile(filename string) (ResumeData, error) {
+	return resolveFile(filename, FromFile, nil)
+}
+
+// ResolveFileStrict is a variant of "ResolveFile()" that uses strict decoding (see "FromFileStrict()") for every
+// file in the chain.
+func ResolveFileStrict(filename string) (ResumeData, error) {
+	return resolveFile(filename, FromFileStrict, nil)
+}
+
+func resolveFile(filename string, load func(string) (ResumeData, error), chain []string) (ResumeData, error) {
+	absolute, err := filepath.Abs(filename)
+	if err != nil {
+		return ResumeData{}, err
+	}
+	for _, ancestor := range chain {
+		if ancestor == absolute {
+			return ResumeData{}, ExtendsCycleError{Chain: append(chain, absolute)}
+		}
+	}
+	resumeData, err := load(filename)
+	if err != nil {
+		return ResumeData{}, err
+	}
+	extends, ok := resumeData.Extra[ExtendsProperty]
+	if !ok {
+		return resumeData, nil
+	}
+	baseFilename, ok := extends.(string)
+	if !ok || strings.TrimSpace(baseFilename) == "" {
+		return ResumeData{}, fmt.Errorf("The \"%s\" property in %s must be a filename", ExtendsProperty, filename)
+	}
+	if !filepath.IsAbs(baseFilename) {
+		baseFilename = filepath.Join(filepath.Dir(filename), baseFilename)
+	}
+	base, err := resolveFile(baseFilename, load, append(chain, absolute))
+	if err != nil {
+		return ResumeData{}, err
+	}
+
+	patch, err := overlayDocument(filename, resumeData)
+	if err != nil {
+		return ResumeData{}, err
+	}
+	delete(patch, ExtendsProperty)
+	baseDocument, err := toGenericDocument(base)
+	if err != nil {
+		return ResumeData{}, err
+	}
+	jsonBytes, err := json.Marshal(mergePatch(baseDocument, patch))
+	if err != nil {
+		return ResumeData{}, err
+	}
+	return FromJsonReader(bytes.NewReader(jsonBytes))
+}
+
+// overlayDocument returns an overlay file's contents in the generic form used by "encoding/json".  JSON, YAML and
+// TOML files are read as written, so that absent properties can be told apart from blank ones (and in JSON and YAML,
+// nulls are kept).  Any other format is read through the already-decoded resume data, with blank values dropped.
+func overlayDocument(filename string, resumeData ResumeData) (map[string]interface{}, error) {
+	codec, err := DetectFileFormat(filename)
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	switch codec.Name() {
+	case "json", "yaml", "toml":
+		fileBytes, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		switch codec.Name() {
+		case "json":
+			err = json.Unmarshal(fileBytes, &document)
+		case "yaml":
+			document, err = decodeYamlDocument(fileBytes)
+		case "toml":
+			var tomlDocument map[string]interface{}
+			_, err = toml.Decode(string(fileBytes), &tomlDocument)
+			document = tomlDocument
+		}
+		if err != nil {
+			return nil, err
+		}
+		document = coerceGeneric(document, resumeDataType)
+	default:
+		generic, err := toGenericDocument(resumeData)
+		if err != nil {
+			return nil, err
+		}
+		document = withoutBlanks(generic)
+	}
+	patch, ok := document.(map[string]interface{})
+	if !ok {
+		return map[string]interface{}{}, nil
+	}
+	return patch, nil
+}
+
+// toGenericDocument converts resume data into the generic form used by "encoding/json".
+func toGenericDocument(resumeData ResumeData) (map[string]interface{}, error) {
+	jsonBytes, err := json.Marshal(resumeData)
+	if err != nil {
+		return nil, err
+	}
+	var document map[string]interface{}
+	err = json.Unmarshal(jsonBytes, &document)
+	return document, err
+}
+
+// withoutBlanks removes blank strings, zero numbers, nulls, and empty lists and objects from a generic value.
+func withoutBlanks(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		result := map[string]interface{}{}
+		for key, item := range typed {
+			if item = withoutBlanks(item); item != nil {
+				result[key] = item
+			}
+		}
+		if len(result) == 0 {
+			return nil
+		}
+		return result
+	case []interface{}:
+		var result []interface{}
+		for _, item := range typed {
+			if item = withoutBlanks(item); item != nil {
+				result = append(result, item)
+			}
+		}
+		if len(result) == 0 {
+			return nil
+		}
+		return result
+	case string:
+		if typed == "" {
+			return nil
+		}
+	case float64:
+		if typed == 0 {
+			return nil
+		}
+	}
+	return value
+}
+
+// mergePatch applies a JSON Merge Patch (RFC 7396) to a generic value, with the keyed list extension described in
+// "ResolveFile()".  Neither argument is modified.
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	result := map[string]interface{}{}
+	if targetObject, ok := target.(map[string]interface{}); ok {
+		for key, value := range targetObject {
+			result[key] = value
+		}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+		keys, keyed := keyedLists[key]
+		targetList, targetIsList := result[key].([]interface{})
+		patchList, patchIsList := value.([]interface{})
+		if keyed && targetIsList && patchIsList {
+			result[key] = mergeKeyedList(targetList, patchList, keys)
+		} else {
+			result[key] = mergePatch(result[key], value)
+		}
+	}
+	return result
+}
+
+func mergeKeyedList(target, patch []interface{}, keys []string) []interface{} {
+	result := append([]interface{}{}, target...)
+	for _, entry := range patch {
+		entryObject, ok := entry.(map[string]interface{})
+		if !ok {
+			result = append(result, entry)
+			continue
+		}
+		match := -1
+		if fields := keyFields(entryObject, keys); len(fields) > 0 {
+			for index, candidate := range result {
+				if candidateObject, ok := candidate.(map[string]interface{}); ok && matchesKeyFields(candidateObject, fields) {
+					match = index
+					break
+				}
+			}
+		}
+		if deleted, _ := entryObject[DeleteDirective].(bool); deleted {
+			if match >= 0 {
+				result = append(result[:match], result[match+1:]...)
+			}
+			continue
+		}
+		entryPatch := map[string]interface{}{}
+		for key, value := range entryObject {
+			if key != DeleteDirective {
+				entryPatch[key] = value
+			}
+		}
+		if match >= 0 {
+			result[match] = mergePatch(result[match], entryPatch)
+		} else {
+			result = append(result, mergePatch(nil, entryPatch))
+		}
+	}
+	return result
+}
+
+// keyFields returns the key properties that an overlay's list entry specifies (i.e. those present and not blank).
+func keyFields(entry map[string]interface{}, keys []string) map[string]interface{} {
+	fields := map[string]interface{}{}
+	for _, key := range keys {
+		if value, ok := entry[key]; ok && value != nil && value != "" {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
+func matchesKeyFields(entry map[string]interface{}, fields map[string]interface{}) bool {
+	for key, value := range fields {
+		if fmt.Sprint(entry[key]) != fmt.Sprint(value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package data_test
+
+import (
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, filename, contents string) {
+	testutils.DeleteFileIfExists(t, filename)
+	if err := ioutil.WriteFile(filename, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolveFile(t *testing.T) {
+	baseFilename := filepath.Join(os.TempDir(), "testresume-base.xml")
+	testutils.DeleteFileIfExists(t, baseFilename)
+	defer testutils.DeleteFileIfExists(t, baseFilename)
+	overlayFilename := filepath.Join(os.TempDir(), "testresume-overlay.json")
+	defer testutils.DeleteFileIfExists(t, overlayFilename)
+
+	if err := data.ToXmlFile(testutils.GenerateTestResumeData(), baseFilename); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, overlayFilename, `{
+  "extends": "testresume-base.xml",
+  "basics": {"summary": "Tailored for Chotchkie's", "phone": null},
+  "work": [
+    {"company": "Initech", "website": "http://initech.example.com"},
+    {"company": "Chotchkie's", "position": "Waiter", "startDate": "2000-01-01"}
+  ],
+  "additionalWork": [{"company": "Flingers", "$delete": true}],
+  "skills": [{"name": "Communication", "level": "Expert"}],
+  "languages": [{"language": "English"}]
+}`)
+
+	resolved, err := data.ResolveFile(overlayFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := testutils.GenerateTestResumeData()
+	expected.Basics.Summary = "Tailored for Chotchkie's"
+	expected.Basics.Phone = ""
+	expected.Work[0].Website = "http://initech.example.com"
+	expected.Work = append(expected.Work, data.Work{Company: "Chotchkie's", Position: "Waiter", StartDate: "2000-01-01"})
+	expected.AdditionalWork = []data.Work{}
+	expected.Skills[1].Level = "Expert"
+	// Lists other than the keyed ones are replaced wholesale
+	expected.Languages = []data.Language{{Language: "English"}}
+
+	if report := data.Diff(expected, resolved); !report.Empty() {
+		t.Fatalf("Unexpected resolved data:\n%s", report)
+	}
+	if _, ok := resolved.Extra[data.ExtendsProperty]; ok {
+		t.Fatal("The \"extends\" property should not be in the resolved data")
+	}
+
+	// Strict mode accepts the "extends" property and "$delete" directives
+	if _, err := data.ResolveFileStrict(overlayFilename); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolveFile_Chain(t *testing.T) {
+	baseFilename := filepath.Join(os.TempDir(), "testresume-base.json")
+	defer testutils.DeleteFileIfExists(t, baseFilename)
+	middleFilename := filepath.Join(os.TempDir(), "testresume-middle.yaml")
+	defer testutils.DeleteFileIfExists(t, middleFilename)
+	overlayFilename := filepath.Join(os.TempDir(), "testresume-overlay.toml")
+	defer testutils.DeleteFileIfExists(t, overlayFilename)
+
+	writeTestFile(t, baseFilename, `{"basics": {"name": "Peter Gibbons", "label": "Programmer"}}`)
+	writeTestFile(t, middleFilename, "extends: testresume-base.json\nbasics:\n  label: Consultant\n  email: peter@example.com\n")
+	writeTestFile(t, overlayFilename, "extends = \"testresume-middle.yaml\"\n\n[basics]\nlabel = \"Waiter\"\n")
+
+	resolved, err := data.ResolveFile(overlayFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := data.Basics{Name: "Peter Gibbons", Label: "Waiter", Email: "peter@example.com"}
+	if !reflect.DeepEqual(resolved.Basics, expected) {
+		t.Fatalf("Expected %+v, found %+v", expected, resolved.Basics)
+	}
+
+	// Files that don't extend another are loaded as usual
+	if resolved, err = data.ResolveFile(baseFilename); err != nil || resolved.Basics.Label != "Programmer" {
+		t.Fatalf("Unexpected base data: %+v, %v", resolved.Basics, err)
+	}
+}
+
+func TestResolveFile_TomlDelete(t *testing.T) {
+	baseFilename := filepath.Join(os.TempDir(), "testresume-base.json")
+	defer testutils.DeleteFileIfExists(t, baseFilename)
+	overlayFilename := filepath.Join(os.TempDir(), "testresume-overlay.toml")
+	defer testutils.DeleteFileIfExists(t, overlayFilename)
+
+	writeTestFile(t, baseFilename, `{"work": [{"company": "Initech"}, {"company": "Chotchkie's"}]}`)
+	// TOML has no null to remove a property with, but keyed list entries can still be deleted
+	writeTestFile(t, overlayFilename, "extends = \"testresume-base.json\"\n\n[[work]]\ncompany = \"Chotchkie's\"\n\"$delete\" = true\n")
+
+	resolved, err := data.ResolveFile(overlayFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resolved.Work) != 1 || resolved.Work[0].Company != "Initech" {
+		t.Fatalf("Expected the deleted work entry to be removed: %+v", resolved.Work)
+	}
+}
+
+func TestResolveFile_Cycle(t *testing.T) {
+	firstFilename := filepath.Join(os.TempDir(), "testresume-first.json")
+	defer testutils.DeleteFileIfExists(t, firstFilename)
+	secondFilename := filepath.Join(os.TempDir(), "testresume-second.json")
+	defer testutils.DeleteFileIfExists(t, secondFilename)
+
+	writeTestFile(t, firstFilename, `{"extends": "testresume-second.json"}`)
+	writeTestFile(t, secondFilename, `{"extends": "testresume-first.json"}`)
+
+	_, err := data.ResolveFile(firstFilename)
+	cycle, ok := err.(data.ExtendsCycleError)
+	if !ok {
+		t.Fatalf("Expected an ExtendsCycleError, found: %v", err)
+	}
+	if len(cycle.Chain) != 3 || cycle.Chain[0] != cycle.Chain[2] {
+		t.Fatalf("Unexpected cycle: %v", cycle.Chain)
+	}
+}
@@ -0,0 +1,567 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Extra holds any properties found in a resume data file that ResumeFodder does not recognize (e.g. the JSON-Resume
+// "meta" section, "x-" extensions, or fields added by other tools).  Every object in the resume data has an "Extra"
+// field, so that these properties are written back out when the data is saved... rather than silently dropped.
+//
+// Values are held in the same generic form used by "encoding/json":  string, float64, bool, nil,
+// []interface{} and map[string]interface{}.  Values of other types set by code are converted to that form when
+// written out.  In XML, each property becomes a child element.  Non-string values carry a "type" attribute, so that
+// they survive conversion between JSON and XML.  Property names that are not legal XML element names are written as
+// "<extra key="...">" elements instead.
+type Extra map[string]interface{}
+
+var extraType = reflect.TypeOf(Extra{})
+
+// Get returns the value at a dot-separated path (e.g. "meta.canonical", or "x-awards.0.title" to index into an
+// array), or nil if there is no such value.  This is intended for templates, as in:
+//
+//	{{.Extra.Get "meta.version"}}
+func (extra Extra) Get(path string) interface{} {
+	var current interface{} = map[string]interface{}(extra)
+	for _, key := range strings.Split(path, ".") {
+		switch value := current.(type) {
+		case map[string]interface{}:
+			current = value[key]
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(value) {
+				return nil
+			}
+			current = value[index]
+		default:
+			return nil
+		}
+	}
+	return current
+}
+
+// The JSON marshalling methods below all follow the same pattern.  Each converts its receiver to a local type
+// without methods (so that the standard "encoding/json" logic can be used without infinite recursion), and then
+// delegates to the helper functions for handling the "Extra" field.
+
+func (data ResumeData) MarshalJSON() ([]byte, error) {
+	type plain ResumeData
+	return marshalWithExtra(plain(data), data.Extra)
+}
+
+func (data *ResumeData) UnmarshalJSON(jsonBytes []byte) error {
+	type plain ResumeData
+	return unmarshalWithExtra(jsonBytes, (*plain)(data), &data.Extra)
+}
+
+func (basics Basics) MarshalJSON() ([]byte, error) {
+	type plain Basics
+	return marshalWithExtra(plain(basics), basics.Extra)
+}
+
+func (basics *Basics) UnmarshalJSON(jsonBytes []byte) error {
+	type plain Basics
+	return unmarshalWithExtra(jsonBytes, (*plain)(basics), &basics.Extra)
+}
+
+func (location Location) MarshalJSON() ([]byte, error) {
+	type plain Location
+	return marshalWithExtra(plain(location), location.Extra)
+}
+
+func (location *Location) UnmarshalJSON(jsonBytes []byte) error {
+	type plain Location
+	return unmarshalWithExtra(jsonBytes, (*plain)(location), &location.Extra)
+}
+
+func (profile SocialProfile) MarshalJSON() ([]byte, error) {
+	type plain SocialProfile
+	return marshalWithExtra(plain(profile), profile.Extra)
+}
+
+func (profile *SocialProfile) UnmarshalJSON(jsonBytes []byte) error {
+	type plain SocialProfile
+	return unmarshalWithExtra(jsonBytes, (*plain)(profile), &profile.Extra)
+}
+
+func (work Work) MarshalJSON() ([]byte, error) {
+	type plain Work
+	return marshalWithExtra(plain(work), work.Extra)
+}
+
+func (work *Work) UnmarshalJSON(jsonBytes []byte) error {
+	type plain Work
+	return unmarshalWithExtra(jsonBytes, (*plain)(work), &work.Extra)
+}
+
+func (group WorkGroup) MarshalJSON() ([]byte, error) {
+	type plain WorkGroup
+	return marshalWithExtra(plain(group), group.Extra)
+}
+
+func (group *WorkGroup) UnmarshalJSON(jsonBytes []byte) error {
+	type plain WorkGroup
+	return unmarshalWithExtra(jsonBytes, (*plain)(group), &group.Extra)
+}
+
+func (role Role) MarshalJSON() ([]byte, error) {
+	type plain Role
+	return marshalWithExtra(plain(role), role.Extra)
+}
+
+func (role *Role) UnmarshalJSON(jsonBytes []byte) error {
+	type plain Role
+	return unmarshalWithExtra(jsonBytes, (*plain)(role), &role.Extra)
+}
+
+func (volunteer Volunteer) MarshalJSON() ([]byte, error) {
+	type plain Volunteer
+	return marshalWithExtra(plain(volunteer), volunteer.Extra)
+}
+
+func (volunteer *Volunteer) UnmarshalJSON(jsonBytes []byte) error {
+	type plain Volunteer
+	return unmarshalWithExtra(jsonBytes, (*plain)(volunteer), &volunteer.Extra)
+}
+
+func (education Education) MarshalJSON() ([]byte, error) {
+	type plain Education
+	return marshalWithExtra(plain(education), education.Extra)
+}
+
+func (education *Education) UnmarshalJSON(jsonBytes []byte) error {
+	type plain Education
+	return unmarshalWithExtra(jsonBytes, (*plain)(education), &education.Extra)
+}
+
+func (award Award) MarshalJSON() ([]byte, error) {
+	type plain Award
+	return marshalWithExtra(plain(award), award.Extra)
+}
+
+func (award *Award) UnmarshalJSON(jsonBytes []byte) error {
+	type plain Award
+	return unmarshalWithExtra(jsonBytes, (*plain)(award), &award.Extra)
+}
+
+func (certificate Certificate) MarshalJSON() ([]byte, error) {
+	type plain Certificate
+	return marshalWithExtra(plain(certificate), certificate.Extra)
+}
+
+func (certificate *Certificate) UnmarshalJSON(jsonBytes []byte) error {
+	type plain Certificate
+	return unmarshalWithExtra(jsonBytes, (*plain)(certificate), &certificate.Extra)
+}
+
+func (group PublicationGroup) MarshalJSON() ([]byte, error) {
+	type plain PublicationGroup
+	return marshalWithExtra(plain(group), group.Extra)
+}
+
+func (group *PublicationGroup) UnmarshalJSON(jsonBytes []byte) error {
+	type plain PublicationGroup
+	return unmarshalWithExtra(jsonBytes, (*plain)(group), &group.Extra)
+}
+
+func (publication Publication) MarshalJSON() ([]byte, error) {
+	type plain Publication
+	return marshalWithExtra(plain(publication), publication.Extra)
+}
+
+func (publication *Publication) UnmarshalJSON(jsonBytes []byte) error {
+	type plain Publication
+	return unmarshalWithExtra(jsonBytes, (*plain)(publication), &publication.Extra)
+}
+
+func (skill Skill) MarshalJSON() ([]byte, error) {
+	type plain Skill
+	return marshalWithExtra(plain(skill), skill.Extra)
+}
+
+func (skill *Skill) UnmarshalJSON(jsonBytes []byte) error {
+	type plain Skill
+	return unmarshalWithExtra(jsonBytes, (*plain)(skill), &skill.Extra)
+}
+
+func (language Language) MarshalJSON() ([]byte, error) {
+	type plain Language
+	return marshalWithExtra(plain(language), language.Extra)
+}
+
+func (language *Language) UnmarshalJSON(jsonBytes []byte) error {
+	type plain Language
+	return unmarshalWithExtra(jsonBytes, (*plain)(language), &language.Extra)
+}
+
+func (interest Interest) MarshalJSON() ([]byte, error) {
+	type plain Interest
+	return marshalWithExtra(plain(interest), interest.Extra)
+}
+
+func (interest *Interest) UnmarshalJSON(jsonBytes []byte) error {
+	type plain Interest
+	return unmarshalWithExtra(jsonBytes, (*plain)(interest), &interest.Extra)
+}
+
+func (reference Reference) MarshalJSON() ([]byte, error) {
+	type plain Reference
+	return marshalWithExtra(plain(reference), reference.Extra)
+}
+
+func (reference *Reference) UnmarshalJSON(jsonBytes []byte) error {
+	type plain Reference
+	return unmarshalWithExtra(jsonBytes, (*plain)(reference), &reference.Extra)
+}
+
+func (project Project) MarshalJSON() ([]byte, error) {
+	type plain Project
+	return marshalWithExtra(plain(project), project.Extra)
+}
+
+func (project *Project) UnmarshalJSON(jsonBytes []byte) error {
+	type plain Project
+	return unmarshalWithExtra(jsonBytes, (*plain)(project), &project.Extra)
+}
+
+// marshalWithExtra marshals a struct to a JSON object, and then appends any extra properties (in sorted order, so
+// that output is stable).
+func marshalWithExtra(value interface{}, extra Extra) ([]byte, error) {
+	jsonBytes, err := json.Marshal(value)
+	if err != nil || len(extra) == 0 {
+		return jsonBytes, err
+	}
+	known := jsonFieldNames(reflect.TypeOf(value))
+	var buffer bytes.Buffer
+	buffer.Write(jsonBytes[:len(jsonBytes)-1])
+	for _, key := range sortedKeys(extra) {
+		if known[strings.ToLower(key)] {
+			continue
+		}
+		keyBytes, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		valueBytes, err := json.Marshal(extra[key])
+		if err != nil {
+			return nil, err
+		}
+		if buffer.Len() > 1 {
+			buffer.WriteByte(',')
+		}
+		buffer.Write(keyBytes)
+		buffer.WriteByte(':')
+		buffer.Write(valueBytes)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// unmarshalWithExtra unmarshals a JSON object into a struct, and collects any properties that don't match one of
+// the struct's fields.  The extra field is left nil if there are none, so that round-trip copies of resume data
+// compare as equal.
+func unmarshalWithExtra(jsonBytes []byte, target interface{}, extra *Extra) error {
+	if string(bytes.TrimSpace(jsonBytes)) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(jsonBytes, target); err != nil {
+		return err
+	}
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(jsonBytes, &properties); err != nil {
+		return err
+	}
+	known := jsonFieldNames(reflect.TypeOf(target).Elem())
+	for key, rawValue := range properties {
+		// "encoding/json" matches field names case-insensitively, so the same is done here
+		if known[strings.ToLower(key)] {
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(rawValue, &value); err != nil {
+			return err
+		}
+		if *extra == nil {
+			*extra = Extra{}
+		}
+		(*extra)[key] = value
+	}
+	return nil
+}
+
+// jsonFieldNames returns the lower-cased JSON property names used by a struct type's fields.
+func jsonFieldNames(structType reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" || field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[strings.ToLower(name)] = true
+	}
+	return names
+}
+
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MarshalXML writes each extra property as a child element of the enclosing object.  The "start" element is
+// ignored, because the properties are siblings of the object's other fields rather than nested in an element of
+// their own.  Properties named after one of those fields are dropped by "ToXmlWriter()" beforehand (see
+// "withoutShadowedExtra()").
+func (extra Extra) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	for _, key := range sortedKeys(extra) {
+		if err := marshalExtraValue(encoder, key, extra[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withoutShadowedExtra returns a copy of a value in which any extra property named after one of the enclosing
+// object's XML elements is dropped.  Otherwise the property would be written as a duplicate of that element, and
+// read back into the field rather than as an extra property.  As in "marshalWithExtra()", the field wins.
+func withoutShadowedExtra(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Struct:
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		for index := 0; index < value.NumField(); index++ {
+			field := copied.Field(index)
+			if !field.CanSet() {
+				continue
+			}
+			if field.Type() != extraType {
+				field.Set(withoutShadowedExtra(field))
+				continue
+			}
+			extra := field.Interface().(Extra)
+			var kept Extra
+			for key, item := range extra {
+				if _, shadowed := structField(value.Type(), "xml", key); shadowed {
+					continue
+				}
+				if kept == nil {
+					kept = Extra{}
+				}
+				kept[key] = item
+			}
+			if len(kept) < len(extra) {
+				field.Set(reflect.ValueOf(kept))
+			}
+		}
+		return copied
+	case reflect.Slice:
+		if value.IsNil() || value.Type().Elem().Kind() != reflect.Struct {
+			return value
+		}
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for index := 0; index < value.Len(); index++ {
+			copied.Index(index).Set(withoutShadowedExtra(value.Index(index)))
+		}
+		return copied
+	}
+	return value
+}
+
+func marshalExtraValue(encoder *xml.Encoder, key string, value interface{}) error {
+	element := xml.StartElement{Name: xml.Name{Local: key}}
+	if !isXmlName(key) {
+		element = xml.StartElement{
+			Name: xml.Name{Local: "extra"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: key}},
+		}
+	}
+	typeAttr := func(valueType string) {
+		element.Attr = append(element.Attr, xml.Attr{Name: xml.Name{Local: "type"}, Value: valueType})
+	}
+	switch typed := value.(type) {
+	case nil:
+		typeAttr("null")
+		return encodeElement(encoder, element, nil)
+	case string:
+		return encoder.EncodeElement(typed, element)
+	case bool:
+		typeAttr("boolean")
+		return encoder.EncodeElement(strconv.FormatBool(typed), element)
+	case float64:
+		typeAttr("number")
+		return encoder.EncodeElement(strconv.FormatFloat(typed, 'g', -1, 64), element)
+	case json.Number:
+		typeAttr("number")
+		return encoder.EncodeElement(typed.String(), element)
+	case int:
+		typeAttr("number")
+		return encoder.EncodeElement(strconv.Itoa(typed), element)
+	case []interface{}:
+		typeAttr("array")
+		return encodeElement(encoder, element, func() error {
+			for _, item := range typed {
+				if err := marshalExtraValue(encoder, "item", item); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	case map[string]interface{}:
+		if len(typed) == 0 {
+			typeAttr("object")
+		}
+		return encodeElement(encoder, element, func() error {
+			return Extra(typed).MarshalXML(encoder, element)
+		})
+	default:
+		// Values set by code rather than decoded (e.g. int64, []string, map[string]string or a nested Extra) are
+		// first converted to the generic form that "encoding/json" produces
+		jsonBytes, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("Unsupported value type %T for extra property \"%s\": %s", value, key, err)
+		}
+		var generic interface{}
+		if err := json.Unmarshal(jsonBytes, &generic); err != nil {
+			return err
+		}
+		return marshalExtraValue(encoder, key, generic)
+	}
+}
+
+// encodeElement writes an element, with its contents (if any) supplied by a callback.
+func encodeElement(encoder *xml.Encoder, element xml.StartElement, contents func() error) error {
+	if err := encoder.EncodeToken(element); err != nil {
+		return err
+	}
+	if contents != nil {
+		if err := contents(); err != nil {
+			return err
+		}
+	}
+	return encoder.EncodeToken(element.End())
+}
+
+// UnmarshalXML is called once for each unrecognized child element of the enclosing object, and adds it as an extra
+// property.  Repeated elements with the same name are collected into an array.
+func (extra *Extra) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	key, value, err := unmarshalExtraValue(decoder, start)
+	if err != nil {
+		return err
+	}
+	if *extra == nil {
+		*extra = Extra{}
+	}
+	if existing, exists := (*extra)[key]; !exists {
+		(*extra)[key] = value
+	} else if list, isList := existing.([]interface{}); isList {
+		(*extra)[key] = append(list, value)
+	} else {
+		(*extra)[key] = []interface{}{existing, value}
+	}
+	return nil
+}
+
+// unmarshalExtraValue reads an element written by "marshalExtraValue()".  Elements without a "type" attribute (e.g.
+// those written by other tools) are read as strings if they contain only text, or as objects if they contain child
+// elements.  In the latter case, repeated child elements with the same name become an array.
+func unmarshalExtraValue(decoder *xml.Decoder, start xml.StartElement) (string, interface{}, error) {
+	key, valueType := start.Name.Local, ""
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "key" && start.Name.Local == "extra" {
+			key = attr.Value
+		} else if attr.Name.Local == "type" {
+			valueType = attr.Value
+		}
+	}
+
+	var text bytes.Buffer
+	children := map[string]interface{}{}
+	repeated := map[string]bool{}
+	var items []interface{}
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return key, nil, err
+		}
+		switch typed := token.(type) {
+		case xml.CharData:
+			text.Write(typed)
+		case xml.StartElement:
+			childKey, childValue, err := unmarshalExtraValue(decoder, typed)
+			if err != nil {
+				return key, nil, err
+			}
+			if valueType == "array" {
+				items = append(items, childValue)
+			} else if repeated[childKey] {
+				children[childKey] = append(children[childKey].([]interface{}), childValue)
+			} else if existing, exists := children[childKey]; exists {
+				children[childKey] = []interface{}{existing, childValue}
+				repeated[childKey] = true
+			} else {
+				children[childKey] = childValue
+			}
+		case xml.EndElement:
+			return key, extraValueFromXml(valueType, text.String(), children, items), nil
+		}
+	}
+}
+
+func extraValueFromXml(valueType, text string, children map[string]interface{}, items []interface{}) interface{} {
+	switch valueType {
+	case "null":
+		return nil
+	case "boolean":
+		value, _ := strconv.ParseBool(strings.TrimSpace(text))
+		return value
+	case "number":
+		value, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return text
+		}
+		return value
+	case "array":
+		if items == nil {
+			return []interface{}{}
+		}
+		return items
+	case "object":
+		return children
+	}
+	if len(children) > 0 {
+		return children
+	}
+	return text
+}
+
+// isXmlName returns true if a property name can be used as an XML element name as-is.
+func isXmlName(name string) bool {
+	if name == "" || name == "extra" || strings.HasPrefix(strings.ToLower(name), "xml") {
+		return false
+	}
+	for index, char := range name {
+		if unicode.IsLetter(char) || char == '_' {
+			continue
+		}
+		if index > 0 && (unicode.IsDigit(char) || char == '-' || char == '.') {
+			continue
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,162 @@
+package data_test
+
+import (
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const extraJson = `{
+  "$schema": "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json",
+  "basics": {
+    "name": "Peter Gibbons",
+    "x-pronouns": "he/him",
+    "location": {"city": "Austin", "x-timezone": "America/Chicago"}
+  },
+  "work": [
+    {"company": "Initech", "location": "Austin, TX", "x-rating": 4.5, "x-remote": false, "x-manager": null}
+  ],
+  "meta": {
+    "canonical": "https://example.com/resume.json",
+    "version": "v1.0.0",
+    "lastModified": "2017-12-24T15:53:00",
+    "tools": ["resume-cli", {"name": "ResumeFodder", "versions": [1, 2]}],
+    "empty": {}
+  }
+}`
+
+func TestExtraJson(t *testing.T) {
+	resume, err := data.FromJsonString(extraJson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resume.Basics.Name != "Peter Gibbons" || resume.Work[0].Company != "Initech" {
+		t.Fatal("Known fields were not loaded")
+	}
+	if resume.Basics.Extra["x-pronouns"] != "he/him" || resume.Basics.Location.Extra["x-timezone"] != "America/Chicago" {
+		t.Fatalf("Nested extra fields were not captured: %+v", resume.Basics)
+	}
+	if resume.Work[0].Extra["location"] != "Austin, TX" || resume.Work[0].Extra["x-rating"] != 4.5 {
+		t.Fatalf("Work extra fields were not captured: %+v", resume.Work[0].Extra)
+	}
+	if resume.Extra.Get("meta.canonical") != "https://example.com/resume.json" || resume.Extra.Get("meta.tools.1.name") != "ResumeFodder" {
+		t.Fatalf("Top-level extra fields were not captured: %+v", resume.Extra)
+	}
+	if resume.Extra.Get("meta.missing") != nil || resume.Extra.Get("meta.tools.9") != nil {
+		t.Fatal("Expected nil for missing extra fields")
+	}
+
+	json, err := data.ToJsonString(resume)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(json, `"x-pronouns": "he/him"`) || !strings.Contains(json, `"$schema"`) {
+		t.Fatalf("Extra fields were not written back out:\n%s", json)
+	}
+	fromJson, err := data.FromJsonString(json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resume, fromJson) {
+		t.Fatal("Resume data with extra fields doesn't match after JSON conversion")
+	}
+}
+
+func TestExtraJsonToXml(t *testing.T) {
+	resume, err := data.FromJsonString(extraJson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xml, err := data.ToXmlString(resume)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(xml, `<extra key="$schema">`) || !strings.Contains(xml, `<x-rating type="number">4.5</x-rating>`) {
+		t.Fatalf("Extra fields were not written to XML as expected:\n%s", xml)
+	}
+	fromXml, err := data.FromXmlString(xml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resume, fromXml) {
+		t.Fatalf("Resume data with extra fields doesn't match after XML conversion:\n%+v\n%+v", resume.Extra, fromXml.Extra)
+	}
+}
+
+func TestExtraForeignXml(t *testing.T) {
+	// XML written by some other tool, with elements that ResumeFodder doesn't recognize
+	resume, err := data.FromXmlString(`<resume>
+  <basics><name>Peter Gibbons</name><nickname>Pete</nickname></basics>
+  <work>
+    <company>Initech</company>
+    <office><floor>3</floor><cubicle>B-12</cubicle></office>
+    <coworker>Michael</coworker>
+    <coworker>Samir</coworker>
+  </work>
+</resume>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resume.Basics.Extra["nickname"] != "Pete" {
+		t.Fatalf("Unrecognized text element was not captured: %+v", resume.Basics.Extra)
+	}
+	if resume.Work[0].Extra.Get("office.cubicle") != "B-12" {
+		t.Fatalf("Unrecognized nested element was not captured: %+v", resume.Work[0].Extra)
+	}
+	if !reflect.DeepEqual(resume.Work[0].Extra["coworker"], []interface{}{"Michael", "Samir"}) {
+		t.Fatalf("Unexpected value for repeated element: %+v", resume.Work[0].Extra)
+	}
+}
+
+func TestExtraXml_NonGenericValues(t *testing.T) {
+	// Extra fields set by code can hold values of any type that "encoding/json" can marshal
+	resume := data.ResumeData{Basics: data.Basics{Name: "Peter Gibbons", Extra: data.Extra{
+		"x-count":   int64(3),
+		"x-aliases": []string{"Pete", "Peter"},
+		"x-labels":  map[string]string{"team": "Platform"},
+		"x-nested":  data.Extra{"rating": 4.5},
+	}}}
+	xml, err := data.ToXmlString(resume)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromXml, err := data.FromXmlString(xml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := data.Extra{
+		"x-count":   3.0,
+		"x-aliases": []interface{}{"Pete", "Peter"},
+		"x-labels":  map[string]interface{}{"team": "Platform"},
+		"x-nested":  map[string]interface{}{"rating": 4.5},
+	}
+	if !reflect.DeepEqual(fromXml.Basics.Extra, expected) {
+		t.Fatalf("Unexpected extra fields after XML conversion: %+v\n%s", fromXml.Basics.Extra, xml)
+	}
+}
+
+func TestExtraXml_ShadowedKeys(t *testing.T) {
+	// An extra property named after a known element can't be written without duplicating that element
+	resume := data.ResumeData{Work: []data.Work{{
+		Company: "Initech",
+		Extra:   data.Extra{"company": "Initrode", "x-rating": "4.5"},
+	}}}
+	xml, err := data.ToXmlString(resume)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(xml, "Initrode") {
+		t.Fatalf("Expected the shadowed extra property to be dropped:\n%s", xml)
+	}
+	fromXml, err := data.FromXmlString(xml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromXml.Work[0].Company != "Initech" || !reflect.DeepEqual(fromXml.Work[0].Extra, data.Extra{"x-rating": "4.5"}) {
+		t.Fatalf("Unexpected work entry after XML conversion: %+v", fromXml.Work[0])
+	}
+	if _, ok := resume.Work[0].Extra["company"]; !ok {
+		t.Fatal("The original data should be left unmodified")
+	}
+}
@@ -0,0 +1,59 @@
+package data_test
+
+import (
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"testing"
+)
+
+func TestAllWorkGroups(t *testing.T) {
+	resume := testutils.GenerateTestResumeData()
+	groups := resume.AllWorkGroups()
+	expected := []string{"Professional Experience", "Academic Work Experience", "Consulting"}
+	if len(groups) != len(expected) {
+		t.Fatalf("Expected %d work groups, found %d", len(expected), len(groups))
+	}
+	for index, name := range expected {
+		if groups[index].Name != name || len(groups[index].Work) != 1 {
+			t.Fatalf("Expected work group %d to be \"%s\" with one entry, found: %+v", index, name, groups[index])
+		}
+	}
+
+	// Legacy fields with no records are skipped
+	resume.Work = nil
+	resume.AdditionalWork = nil
+	groups = resume.AllWorkGroups()
+	if len(groups) != 1 || groups[0].Name != "Consulting" {
+		t.Fatalf("Expected only the \"Consulting\" work group, found: %+v", groups)
+	}
+}
+
+func TestAllPublicationGroups(t *testing.T) {
+	resume := testutils.GenerateTestResumeData()
+	groups := resume.AllPublicationGroups()
+	expected := []string{"Publications", "Academic Publications", "Technical Reviewer"}
+	if len(groups) != len(expected) {
+		t.Fatalf("Expected %d publication groups, found %d", len(expected), len(groups))
+	}
+	for index, name := range expected {
+		if groups[index].Name != name || len(groups[index].Publications) != 1 {
+			t.Fatalf("Expected publication group %d to be \"%s\" with one entry, found: %+v", index, name, groups[index])
+		}
+	}
+}
+
+func TestLegacyFieldsWithoutGroups(t *testing.T) {
+	resume, err := data.FromJsonString(`{
+  "workLabel": "Recent",
+  "work": [{"company": "Initech"}],
+  "additionalWorkLabel": "Prior",
+  "additionalWork": [{"company": "Flingers"}]
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	groups := resume.AllWorkGroups()
+	if len(groups) != 2 || groups[0].Name != "Recent" || groups[1].Work[0].Company != "Flingers" {
+		t.Fatalf("Legacy work fields were not exposed as groups: %+v", groups)
+	}
+}
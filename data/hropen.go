@@ -0,0 +1,697 @@
+package data
+
+import (
+	_ "embed"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// The types below model the parts of the HR Open Standards (formerly HR-XML) candidate schema that have a
+// counterpart in ResumeData.  The same structure is used for both the JSON and XML forms, with JSON property names
+// following HR Open 4 and XML element names following HR-XML 3.  In XML, each type collects unrecognized child
+// elements in an "Unknown" field, so that they can be reported after an import.
+
+type hrOpenDocument struct {
+	Candidate hrOpenCandidate `json:"candidate"`
+}
+
+type hrOpenCandidate struct {
+	XMLName  xml.Name        `xml:"Candidate" json:"-"`
+	Xmlns    string          `xml:"xmlns,attr,omitempty" json:"-"`
+	Person   hrOpenPerson    `xml:"CandidatePerson" json:"person"`
+	Profiles []hrOpenProfile `xml:"CandidateProfile" json:"profiles,omitempty"`
+	Unknown  Extra           `xml:",any" json:"-"`
+}
+
+type hrOpenPerson struct {
+	Name          hrOpenName          `xml:"PersonName" json:"name"`
+	Communication hrOpenCommunication `xml:"Communication" json:"communication"`
+	Unknown       Extra               `xml:",any" json:"-"`
+}
+
+type hrOpenName struct {
+	FormattedName string `xml:"FormattedName" json:"formattedName"`
+	Given         string `xml:"GivenName,omitempty" json:"given,omitempty"`
+	Family        string `xml:"FamilyName,omitempty" json:"family,omitempty"`
+	Unknown       Extra  `xml:",any" json:"-"`
+}
+
+type hrOpenCommunication struct {
+	Address []hrOpenAddress `xml:"Address" json:"address,omitempty"`
+	Phone   []hrOpenPhone   `xml:"Phone" json:"phone,omitempty"`
+	Email   []hrOpenEmail   `xml:"Email" json:"email,omitempty"`
+	Web     []hrOpenWeb     `xml:"Web" json:"web,omitempty"`
+	Unknown Extra           `xml:",any" json:"-"`
+}
+
+type hrOpenAddress struct {
+	Line               string `xml:"AddressLine,omitempty" json:"line,omitempty"`
+	City               string `xml:"CityName,omitempty" json:"city,omitempty"`
+	CountrySubDivision string `xml:"CountrySubDivisionCode,omitempty" json:"countrySubDivision,omitempty"`
+	CountryCode        string `xml:"CountryCode,omitempty" json:"countryCode,omitempty"`
+	PostalCode         string `xml:"PostalCode,omitempty" json:"postalCode,omitempty"`
+	Unknown            Extra  `xml:",any" json:"-"`
+}
+
+type hrOpenPhone struct {
+	FormattedNumber string `xml:"FormattedNumber" json:"formattedNumber"`
+	Unknown         Extra  `xml:",any" json:"-"`
+}
+
+type hrOpenEmail struct {
+	Address string `xml:"URI" json:"address"`
+	Unknown Extra  `xml:",any" json:"-"`
+}
+
+type hrOpenWeb struct {
+	Name    string `xml:"Name,omitempty" json:"name,omitempty"`
+	Url     string `xml:"URI" json:"url"`
+	Unknown Extra  `xml:",any" json:"-"`
+}
+
+type hrOpenProfile struct {
+	Objective        string                `xml:"Objective,omitempty" json:"objective,omitempty"`
+	ExecutiveSummary string                `xml:"ExecutiveSummary,omitempty" json:"executiveSummary,omitempty"`
+	Employment       []hrOpenEmployer      `xml:"EmploymentHistory>EmployerHistory" json:"employment,omitempty"`
+	Education        []hrOpenEducation     `xml:"EducationHistory>EducationOrganizationAttendance" json:"education,omitempty"`
+	Competencies     []hrOpenCompetency    `xml:"Qualifications>PersonCompetency" json:"competencies,omitempty"`
+	Certifications   []hrOpenCertification `xml:"Certifications>Certification" json:"certifications,omitempty"`
+	Publications     []hrOpenPublication   `xml:"PublicationHistory>Publication" json:"publications,omitempty"`
+	Languages        []hrOpenLanguage      `xml:"Languages>Language" json:"languages,omitempty"`
+	Unknown          Extra                 `xml:",any" json:"-"`
+}
+
+type hrOpenEmployer struct {
+	OrganizationName string           `xml:"OrganizationName" json:"organizationName"`
+	Url              string           `xml:"OrganizationURL,omitempty" json:"url,omitempty"`
+	Start            string           `xml:"StartDate,omitempty" json:"start,omitempty"`
+	End              string           `xml:"EndDate,omitempty" json:"end,omitempty"`
+	Current          bool             `xml:"CurrentEmploymentIndicator,omitempty" json:"current,omitempty"`
+	Description      string           `xml:"Description,omitempty" json:"description,omitempty"`
+	Positions        []hrOpenPosition `xml:"PositionHistory" json:"positionHistories,omitempty"`
+	Unknown          Extra            `xml:",any" json:"-"`
+}
+
+type hrOpenPosition struct {
+	Title       string `xml:"PositionTitle" json:"title"`
+	Start       string `xml:"StartDate,omitempty" json:"start,omitempty"`
+	End         string `xml:"EndDate,omitempty" json:"end,omitempty"`
+	Current     bool   `xml:"CurrentIndicator,omitempty" json:"current,omitempty"`
+	Description string `xml:"Description,omitempty" json:"description,omitempty"`
+	Unknown     Extra  `xml:",any" json:"-"`
+}
+
+type hrOpenEducation struct {
+	Institution string         `xml:"OrganizationName" json:"institution"`
+	Start       string         `xml:"StartDate,omitempty" json:"start,omitempty"`
+	End         string         `xml:"EndDate,omitempty" json:"end,omitempty"`
+	Degrees     []hrOpenDegree `xml:"EducationDegree" json:"educationDegrees,omitempty"`
+	Courses     []string       `xml:"Course,omitempty" json:"courses,omitempty"`
+	Unknown     Extra          `xml:",any" json:"-"`
+}
+
+type hrOpenDegree struct {
+	Name    string   `xml:"DegreeName,omitempty" json:"name,omitempty"`
+	Majors  []string `xml:"DegreeMajor>Name,omitempty" json:"majors,omitempty"`
+	Score   string   `xml:"DegreeGrade,omitempty" json:"score,omitempty"`
+	Unknown Extra    `xml:",any" json:"-"`
+}
+
+type hrOpenCompetency struct {
+	Name             string   `xml:"CompetencyName" json:"name"`
+	ProficiencyLevel string   `xml:"ProficiencyLevel,omitempty" json:"proficiencyLevel,omitempty"`
+	Keywords         []string `xml:"Keyword,omitempty" json:"keywords,omitempty"`
+	Unknown          Extra    `xml:",any" json:"-"`
+}
+
+type hrOpenCertification struct {
+	Name             string `xml:"CertificationName" json:"name"`
+	IssuingAuthority string `xml:"IssuingAuthorityName,omitempty" json:"issuingAuthority,omitempty"`
+	EffectiveDate    string `xml:"FirstIssuedDate,omitempty" json:"effectiveDate,omitempty"`
+	Link             string `xml:"Link,omitempty" json:"link,omitempty"`
+	Unknown          Extra  `xml:",any" json:"-"`
+}
+
+type hrOpenPublication struct {
+	Title           string `xml:"Title" json:"title"`
+	Publisher       string `xml:"PublisherName,omitempty" json:"publisher,omitempty"`
+	PublicationDate string `xml:"PublicationDate,omitempty" json:"publicationDate,omitempty"`
+	Link            string `xml:"Link,omitempty" json:"link,omitempty"`
+	Abstract        string `xml:"Abstract,omitempty" json:"abstract,omitempty"`
+	ISBN            string `xml:"ISBN,omitempty" json:"isbn,omitempty"`
+	Unknown         Extra  `xml:",any" json:"-"`
+}
+
+type hrOpenLanguage struct {
+	Name        string `xml:"LanguageName" json:"name"`
+	Proficiency string `xml:"ProficiencyLevel,omitempty" json:"proficiency,omitempty"`
+	Unknown     Extra  `xml:",any" json:"-"`
+}
+
+var hrOpenDocumentType = reflect.TypeOf(hrOpenDocument{})
+
+// hrOpenNamespace is the XML namespace written on exported HR-XML candidate documents.
+const hrOpenNamespace = "http://www.hr-xml.org/3"
+
+// hrOpenSchemaJson is the bundled schema against which HR Open documents are validated, in both directions.  See
+// "schema/hropen-candidate.json".  This is not the official HR Open schema, but a hand-written subset of it covering
+// only the properties that ResumeFodder reads and writes.  Other properties are allowed without being checked, and so
+// a document passing validation here may still be rejected by tools that check against the full schema.
+//
+//go:embed schema/hropen-candidate.json
+var hrOpenSchemaJson []byte
+
+var hrOpenSchema = mustLoadSchema(hrOpenSchemaJson)
+
+// FromHrOpenJson loads resume data from an HR Open candidate document in JSON format.  The document is first
+// validated against the bundled candidate schema, and a "SchemaError" is returned if it doesn't match.  The returned
+// report lists every property of the document that has no counterpart in ResumeData.
+func FromHrOpenJson(reader io.Reader) (ResumeData, MappingReport, error) {
+	var document interface{}
+	if err := json.NewDecoder(reader).Decode(&document); err != nil {
+		return ResumeData{}, MappingReport{}, err
+	}
+	if err := hrOpenSchema.validate("HR Open candidate", document); err != nil {
+		return ResumeData{}, MappingReport{}, err
+	}
+	report := MappingReport{}
+	reportUnmappedGeneric(document, hrOpenDocumentType, "", "HR Open", nil, &report)
+	jsonBytes, err := json.Marshal(document)
+	if err != nil {
+		return ResumeData{}, report, err
+	}
+	var hrOpen hrOpenDocument
+	if err := json.Unmarshal(jsonBytes, &hrOpen); err != nil {
+		return ResumeData{}, report, err
+	}
+	return fromHrOpen(hrOpen.Candidate, &report), report, nil
+}
+
+// FromHrOpenXml loads resume data from an HR-XML candidate document.  See "FromHrOpenJson()".
+func FromHrOpenXml(reader io.Reader) (ResumeData, MappingReport, error) {
+	var candidate hrOpenCandidate
+	if err := xml.NewDecoder(reader).Decode(&candidate); err != nil {
+		return ResumeData{}, MappingReport{}, err
+	}
+	if err := validateHrOpen(candidate); err != nil {
+		return ResumeData{}, MappingReport{}, err
+	}
+	report := MappingReport{}
+	reportUnknownElements(reflect.ValueOf(candidate), "Candidate", "HR Open", &report)
+	return fromHrOpen(candidate, &report), report, nil
+}
+
+// ToHrOpenJson writes resume data as an HR Open candidate document in JSON format.  The document is validated
+// against the bundled candidate schema before it is written, and a "SchemaError" is returned if it doesn't match
+// (e.g. if the resume data has no name).  The returned report lists every non-empty field of the resume data that
+// the candidate schema can't represent.
+func ToHrOpenJson(data ResumeData, writer io.Writer) (MappingReport, error) {
+	candidate, report := toHrOpen(data)
+	if err := validateHrOpen(candidate); err != nil {
+		return report, err
+	}
+	jsonBytes, err := json.MarshalIndent(hrOpenDocument{Candidate: candidate}, "", "  ")
+	if err != nil {
+		return report, err
+	}
+	_, err = writer.Write(jsonBytes)
+	return report, err
+}
+
+// ToHrOpenXml writes resume data as an HR-XML candidate document.  See "ToHrOpenJson()".
+func ToHrOpenXml(data ResumeData, writer io.Writer) (MappingReport, error) {
+	candidate, report := toHrOpen(data)
+	if err := validateHrOpen(candidate); err != nil {
+		return report, err
+	}
+	candidate.Xmlns = hrOpenNamespace
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return report, err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(candidate); err != nil {
+		return report, err
+	}
+	return report, encoder.Flush()
+}
+
+// validateHrOpen checks a candidate against the bundled schema, by way of its JSON form.
+func validateHrOpen(candidate hrOpenCandidate) error {
+	jsonBytes, err := json.Marshal(hrOpenDocument{Candidate: candidate})
+	if err != nil {
+		return err
+	}
+	var document interface{}
+	if err := json.Unmarshal(jsonBytes, &document); err != nil {
+		return err
+	}
+	return hrOpenSchema.validate("HR Open candidate", document)
+}
+
+// reportUnknownElements walks a struct decoded from XML, reporting the unrecognized child elements collected in
+// each of its "Unknown" fields by their path (e.g. "Candidate.CandidatePerson.PersonName.MiddleName").
+func reportUnknownElements(value reflect.Value, path, source string, report *MappingReport) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if !value.IsNil() {
+			reportUnknownElements(value.Elem(), path, source, report)
+		}
+	case reflect.Slice:
+		for index := 0; index < value.Len(); index++ {
+			reportUnknownElements(value.Index(index), path, source, report)
+		}
+	case reflect.Struct:
+		for index := 0; index < value.NumField(); index++ {
+			field := value.Type().Field(index)
+			if field.Type == extraType {
+				for _, key := range sortedKeys(value.Field(index).Interface().(Extra)) {
+					report.addUnmapped(source, path+"."+key)
+				}
+				continue
+			}
+			name := strings.Split(field.Tag.Get("xml"), ",")[0]
+			if name == "" || field.PkgPath != "" || field.Name == "XMLName" {
+				continue
+			}
+			reportUnknownElements(value.Field(index), path+"."+strings.Replace(name, ">", ".", -1), source, report)
+		}
+	}
+}
+
+// toHrOpen maps resume data onto the HR Open candidate structure, reporting any non-empty fields that are left
+// behind.
+func toHrOpen(data ResumeData) (hrOpenCandidate, MappingReport) {
+	report := MappingReport{}
+	unmapped := func(source, field string, value interface{}) {
+		if !isEmptyValue(reflect.ValueOf(value)) {
+			report.addUnmapped(source, field)
+		}
+	}
+	candidate := hrOpenCandidate{}
+	profile := hrOpenProfile{}
+
+	// Basics
+	basics := data.Basics
+	candidate.Person.Name.FormattedName = basics.Name
+	if names := strings.Fields(basics.Name); len(names) > 1 {
+		candidate.Person.Name.Given = strings.Join(names[:len(names)-1], " ")
+		candidate.Person.Name.Family = names[len(names)-1]
+	}
+	communication := &candidate.Person.Communication
+	location := basics.Location
+	address := hrOpenAddress{
+		Line:               location.Address,
+		City:               location.City,
+		CountrySubDivision: location.Region,
+		CountryCode:        location.CountryCode,
+		PostalCode:         location.PostalCode,
+	}
+	if !isEmptyValue(reflect.ValueOf(address)) {
+		communication.Address = []hrOpenAddress{address}
+	}
+	if basics.Phone != "" {
+		communication.Phone = []hrOpenPhone{{FormattedNumber: basics.Phone}}
+	}
+	if basics.Email != "" {
+		communication.Email = []hrOpenEmail{{Address: basics.Email}}
+	}
+	if basics.Website != "" {
+		communication.Web = []hrOpenWeb{{Url: basics.Website}}
+	}
+	for _, socialProfile := range basics.Profiles {
+		if socialProfile.Url == "" {
+			unmapped("basics", "profiles.username", socialProfile.Username)
+			continue
+		}
+		communication.Web = append(communication.Web, hrOpenWeb{Name: socialProfile.Network, Url: socialProfile.Url})
+	}
+	profile.Objective = basics.Label
+	profile.ExecutiveSummary = joinBullets(basics.Summary, basics.Highlights)
+	unmapped("basics", "picture", basics.Picture)
+	unmapped("basics", "degree", basics.Degree)
+	for _, key := range sortedKeys(basics.Extra) {
+		unmapped("basics", key, basics.Extra[key])
+	}
+
+	// Work, with each role at an employer as a position history
+	for _, group := range data.AllWorkGroups() {
+		for _, work := range group.Work {
+			employer := hrOpenEmployer{
+				OrganizationName: work.Company,
+				Url:              work.Website,
+				Start:            hrOpenDate(work.StartDate, &report),
+				End:              hrOpenDate(work.EndDate, &report),
+				Current:          work.EndDate.IsPresent(),
+			}
+			if len(work.Roles) > 0 {
+				employer.Description = joinBullets(work.Summary, highlightTexts(work.Highlights))
+			}
+			for _, role := range work.AllRoles() {
+				employer.Positions = append(employer.Positions, hrOpenPosition{
+					Title:       role.Title,
+					Start:       hrOpenDate(role.StartDate, &report),
+					End:         hrOpenDate(role.EndDate, &report),
+					Current:     role.EndDate.IsPresent() || (role.EndDate.IsBlank() && !role.StartDate.IsBlank()),
+					Description: joinBullets(role.Summary, highlightTexts(role.Highlights)),
+				})
+			}
+			profile.Employment = append(profile.Employment, employer)
+			unmapped("work", "tags", work.Tags)
+			for _, key := range sortedKeys(work.Extra) {
+				unmapped("work", key, work.Extra[key])
+			}
+		}
+	}
+	unmapped("workLabel", "workLabel", data.WorkLabel)
+	unmapped("additionalWorkLabel", "additionalWorkLabel", data.AdditionalWorkLabel)
+	for _, group := range data.WorkGroups {
+		unmapped("workGroups", "name", group.Name)
+	}
+
+	// Education
+	for _, education := range data.Education {
+		attendance := hrOpenEducation{
+			Institution: education.Institution,
+			Start:       hrOpenDate(education.StartDate, &report),
+			End:         hrOpenDate(education.EndDate, &report),
+			Courses:     education.Courses,
+		}
+		degree := hrOpenDegree{Name: education.StudyType, Score: education.GPA}
+		if education.Area != "" {
+			degree.Majors = []string{education.Area}
+		}
+		if !isEmptyValue(reflect.ValueOf(degree)) {
+			attendance.Degrees = []hrOpenDegree{degree}
+		}
+		profile.Education = append(profile.Education, attendance)
+		unmapped("education", "tags", education.Tags)
+	}
+
+	// Skills, certificates, publications and languages
+	for _, skill := range data.Skills {
+		profile.Competencies = append(profile.Competencies,
+			hrOpenCompetency{Name: skill.Name, ProficiencyLevel: skill.Level, Keywords: skill.Keywords})
+		unmapped("skills", "tags", skill.Tags)
+	}
+	for _, certificate := range data.Certificates {
+		profile.Certifications = append(profile.Certifications, hrOpenCertification{
+			Name:             certificate.Name,
+			IssuingAuthority: certificate.Issuer,
+			EffectiveDate:    hrOpenDate(certificate.Date, &report),
+			Link:             certificate.Url,
+		})
+	}
+	for _, group := range data.AllPublicationGroups() {
+		for _, publication := range group.Publications {
+			profile.Publications = append(profile.Publications, hrOpenPublication{
+				Title:           publication.Name,
+				Publisher:       publication.Publisher,
+				PublicationDate: hrOpenDate(publication.ReleaseDate, &report),
+				Link:            publication.Website,
+				Abstract:        publication.Summary,
+				ISBN:            publication.ISBN,
+			})
+			unmapped("publications", "tags", publication.Tags)
+		}
+	}
+	unmapped("publicationsLabel", "publicationsLabel", data.PublicationsLabel)
+	unmapped("additionalPublicationsLabel", "additionalPublicationsLabel", data.AdditionalPublicationsLabel)
+	for _, group := range data.PublicationGroups {
+		unmapped("publicationGroups", "name", group.Name)
+	}
+	for _, language := range data.Languages {
+		profile.Languages = append(profile.Languages, hrOpenLanguage{Name: language.Language, Proficiency: language.Fluency})
+	}
+
+	// Sections that the candidate schema has no place for
+	unmapped("volunteer", "volunteer", data.Volunteer)
+	unmapped("awards", "awards", data.Awards)
+	unmapped("interests", "interests", data.Interests)
+	unmapped("references", "references", data.References)
+	unmapped("projects", "projects", data.Projects)
+	for _, key := range sortedKeys(data.Extra) {
+		unmapped("resume", key, data.Extra[key])
+	}
+
+	if !isEmptyValue(reflect.ValueOf(profile)) {
+		candidate.Profiles = []hrOpenProfile{profile}
+	}
+	return candidate, report
+}
+
+// fromHrOpen maps the HR Open candidate structure onto resume data.  When a candidate has several profiles, their
+// entries are combined.
+func fromHrOpen(candidate hrOpenCandidate, report *MappingReport) ResumeData {
+	data := ResumeData{Version: SCHEMA_VERSION}
+	person := candidate.Person
+	data.Basics.Name = person.Name.FormattedName
+	if data.Basics.Name == "" {
+		data.Basics.Name = strings.TrimSpace(person.Name.Given + " " + person.Name.Family)
+	}
+	communication := person.Communication
+	if len(communication.Address) > 0 {
+		address := communication.Address[0]
+		data.Basics.Location = Location{
+			Address:     address.Line,
+			PostalCode:  address.PostalCode,
+			City:        address.City,
+			CountryCode: address.CountryCode,
+			Region:      address.CountrySubDivision,
+		}
+	}
+	if len(communication.Phone) > 0 {
+		data.Basics.Phone = communication.Phone[0].FormattedNumber
+	}
+	if len(communication.Email) > 0 {
+		data.Basics.Email = communication.Email[0].Address
+	}
+	for _, web := range communication.Web {
+		if web.Name == "" && data.Basics.Website == "" {
+			data.Basics.Website = web.Url
+		} else {
+			data.Basics.Profiles = append(data.Basics.Profiles, SocialProfile{Network: web.Name, Url: web.Url})
+		}
+	}
+
+	for _, profile := range candidate.Profiles {
+		if data.Basics.Label == "" {
+			data.Basics.Label = profile.Objective
+		}
+		if data.Basics.Summary == "" && len(data.Basics.Highlights) == 0 {
+			data.Basics.Summary, data.Basics.Highlights = splitBullets(profile.ExecutiveSummary)
+		}
+
+		for _, employer := range profile.Employment {
+			work := Work{
+				Company:   employer.OrganizationName,
+				Website:   employer.Url,
+				StartDate: hrOpenResumeDate(employer.Start, report),
+				EndDate:   hrOpenResumeDate(employer.End, report),
+			}
+			if employer.Current {
+				work.EndDate = Present
+			}
+			work.Summary, work.Highlights = splitHighlights(employer.Description)
+			if len(employer.Positions) == 1 && employer.Description == "" {
+				// A single position is folded into the work entry itself
+				position := employer.Positions[0]
+				work.Position = position.Title
+				work.Summary, work.Highlights = splitHighlights(position.Description)
+				if work.StartDate.IsBlank() {
+					work.StartDate = hrOpenResumeDate(position.Start, report)
+				}
+				if work.EndDate.IsBlank() {
+					work.EndDate = hrOpenResumeDate(position.End, report)
+					if position.Current {
+						work.EndDate = Present
+					}
+				}
+			} else {
+				for _, position := range employer.Positions {
+					role := Role{
+						Title:     position.Title,
+						StartDate: hrOpenResumeDate(position.Start, report),
+						EndDate:   hrOpenResumeDate(position.End, report),
+					}
+					if position.Current {
+						role.EndDate = Present
+					}
+					role.Summary, role.Highlights = splitHighlights(position.Description)
+					work.Roles = append(work.Roles, role)
+				}
+				if len(work.Roles) > 0 {
+					work.Position = work.AllRoles()[0].Title
+				}
+			}
+			data.Work = append(data.Work, work)
+		}
+
+		for _, attendance := range profile.Education {
+			education := Education{
+				Institution: attendance.Institution,
+				StartDate:   hrOpenResumeDate(attendance.Start, report),
+				EndDate:     hrOpenResumeDate(attendance.End, report),
+				Courses:     attendance.Courses,
+			}
+			if len(attendance.Degrees) > 0 {
+				degree := attendance.Degrees[0]
+				education.StudyType = degree.Name
+				education.GPA = degree.Score
+				education.Area = strings.Join(degree.Majors, ", ")
+				for _, other := range attendance.Degrees[1:] {
+					report.Notes = append(report.Notes,
+						fmt.Sprintf("HR Open: additional degree \"%s\" at %s was left out", other.Name, attendance.Institution))
+				}
+			}
+			data.Education = append(data.Education, education)
+		}
+
+		for _, competency := range profile.Competencies {
+			data.Skills = append(data.Skills,
+				Skill{Name: competency.Name, Level: competency.ProficiencyLevel, Keywords: competency.Keywords})
+		}
+		for _, certification := range profile.Certifications {
+			data.Certificates = append(data.Certificates, Certificate{
+				Name:   certification.Name,
+				Date:   hrOpenResumeDate(certification.EffectiveDate, report),
+				Issuer: certification.IssuingAuthority,
+				Url:    certification.Link,
+			})
+		}
+		for _, publication := range profile.Publications {
+			data.Publications = append(data.Publications, Publication{
+				Name:        publication.Title,
+				Publisher:   publication.Publisher,
+				ReleaseDate: hrOpenResumeDate(publication.PublicationDate, report),
+				Website:     publication.Link,
+				Summary:     publication.Abstract,
+				ISBN:        publication.ISBN,
+			})
+		}
+		for _, language := range profile.Languages {
+			data.Languages = append(data.Languages, Language{Language: language.Name, Fluency: language.Proficiency})
+		}
+	}
+	return data
+}
+
+// hrOpenDate converts a date to the ISO 8601 form required by the candidate schema.  Blank and "present" dates are
+// left out (ongoing ranges are marked with a "current" indicator instead), as are dates that can't be recognized.
+func hrOpenDate(date Date, report *MappingReport) string {
+	if date.IsBlank() || date.IsPresent() {
+		return ""
+	} else if date.Precision() != PrecisionNone {
+		return string(date)
+	} else if normalized, ok := NormalizeDate(string(date)); ok {
+		return string(normalized)
+	}
+	report.Notes = append(report.Notes, fmt.Sprintf("HR Open: unrecognized date \"%s\" was left out", date))
+	return ""
+}
+
+// hrOpenResumeDate converts a date from a candidate document, which may be a full timestamp.
+func hrOpenResumeDate(text string, report *MappingReport) Date {
+	date, ok := NormalizeDate(text)
+	if !ok {
+		report.Notes = append(report.Notes, fmt.Sprintf("HR Open: unrecognized date \"%s\"", text))
+	}
+	return date
+}
+
+// splitHighlights is a variant of "splitBullets()", which returns the bullet points as highlights.
+func splitHighlights(text string) (string, []Highlight) {
+	summary, bullets := splitBullets(text)
+	var highlights []Highlight
+	for _, bullet := range bullets {
+		highlights = append(highlights, Highlight{Text: bullet})
+	}
+	return summary, highlights
+}
+
+func init() {
+	RegisterCodec(hrOpenXmlCodec{})
+	RegisterCodec(hrOpenJsonCodec{})
+	foreignRoots["Candidate"] = true
+	foreignRoots["candidate"] = true
+}
+
+// hrOpenXmlCodec is the HR-XML candidate format.  Files are recognized by the ".hropen.xml" extension, or by a
+// "Candidate" root element.
+type hrOpenXmlCodec struct{}
+
+func (hrOpenXmlCodec) Name() string {
+	return "hropen-xml"
+}
+
+func (hrOpenXmlCodec) Extensions() []string {
+	return []string{".hropen.xml"}
+}
+
+func (hrOpenXmlCodec) MimeType() string {
+	return "application/xml"
+}
+
+func (codec hrOpenXmlCodec) Decode(reader io.Reader) (ResumeData, error) {
+	data, _, err := codec.DecodeWithReport(reader)
+	return data, err
+}
+
+func (codec hrOpenXmlCodec) Encode(data ResumeData, writer io.Writer) error {
+	_, err := codec.EncodeWithReport(data, writer)
+	return err
+}
+
+func (hrOpenXmlCodec) DecodeWithReport(reader io.Reader) (ResumeData, MappingReport, error) {
+	return FromHrOpenXml(reader)
+}
+
+func (hrOpenXmlCodec) EncodeWithReport(data ResumeData, writer io.Writer) (MappingReport, error) {
+	return ToHrOpenXml(data, writer)
+}
+
+func (hrOpenXmlCodec) Detect(head []byte) bool {
+	return xmlRoot(head) == "Candidate"
+}
+
+// hrOpenJsonCodec is the HR Open candidate format in JSON.  Files are recognized by the ".hropen.json" extension,
+// or by a "candidate" property at the start of the top-level object.
+type hrOpenJsonCodec struct{}
+
+func (hrOpenJsonCodec) Name() string {
+	return "hropen-json"
+}
+
+func (hrOpenJsonCodec) Extensions() []string {
+	return []string{".hropen.json"}
+}
+
+func (hrOpenJsonCodec) MimeType() string {
+	return "application/json"
+}
+
+func (codec hrOpenJsonCodec) Decode(reader io.Reader) (ResumeData, error) {
+	data, _, err := codec.DecodeWithReport(reader)
+	return data, err
+}
+
+func (codec hrOpenJsonCodec) Encode(data ResumeData, writer io.Writer) error {
+	_, err := codec.EncodeWithReport(data, writer)
+	return err
+}
+
+func (hrOpenJsonCodec) DecodeWithReport(reader io.Reader) (ResumeData, MappingReport, error) {
+	return FromHrOpenJson(reader)
+}
+
+func (hrOpenJsonCodec) EncodeWithReport(data ResumeData, writer io.Writer) (MappingReport, error) {
+	return ToHrOpenJson(data, writer)
+}
+
+func (hrOpenJsonCodec) Detect(head []byte) bool {
+	return jsonFirstKey(head) == "candidate"
+}
@@ -0,0 +1,132 @@
+package data_test
+
+import (
+	"bytes"
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHrOpenConversion(t *testing.T) {
+	originalData := testutils.GenerateTestResumeData()
+	for _, name := range []string{"hropen-xml", "hropen-json"} {
+		codec, _ := data.CodecByName(name)
+		reportingCodec := codec.(data.ReportingCodec)
+
+		var buffer bytes.Buffer
+		exportReport, err := reportingCodec.EncodeWithReport(originalData, &buffer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{"basics: x-nickname", "work: tags", "volunteer: volunteer", "awards: awards",
+			"workGroups: name"} {
+			if !hasUnmapped(exportReport, expected) {
+				t.Fatalf("%s export report is missing \"%s\": %v", name, expected, exportReport.Unmapped)
+			}
+		}
+		detected, err := data.DetectFormat(buffer.Bytes())
+		if err != nil || detected.Name() != name {
+			t.Fatalf("Unexpected detected format for %s: %v, %v", name, detected, err)
+		}
+
+		resume, importReport, err := reportingCodec.DecodeWithReport(&buffer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(importReport.Unmapped) > 0 || len(importReport.Notes) > 0 {
+			t.Fatalf("Unexpected %s import report: %+v", name, importReport)
+		}
+		if !reflect.DeepEqual(resume.Basics.Location, originalData.Basics.Location) ||
+			!reflect.DeepEqual(resume.Basics.Highlights, originalData.Basics.Highlights) ||
+			resume.Basics.Summary != originalData.Basics.Summary || resume.Basics.Email != originalData.Basics.Email {
+			t.Fatalf("Unexpected %s basics: %+v", name, resume.Basics)
+		}
+
+		// Every work group is flattened, and roles are kept together under their employer
+		if len(resume.Work) != 3 {
+			t.Fatalf("Expected 3 %s work entries, found %d", name, len(resume.Work))
+		}
+		initech := resume.Work[0]
+		if len(initech.Roles) != 2 || initech.Roles[0].Title != "Senior Software Developer" ||
+			initech.Roles[0].EndDate != data.Present || initech.Summary != originalData.Work[0].Summary ||
+			!reflect.DeepEqual(initech.Roles[1].Highlights, []data.Highlight{{Text: "Filed TPS reports with the new cover sheet."}}) {
+			t.Fatalf("Unexpected %s roles: %+v", name, initech)
+		}
+		flingers := resume.Work[1]
+		if flingers.Position != "Burger Flipper" || flingers.StartDate != "1993-08-01" || flingers.EndDate != "1998-01-31" ||
+			len(flingers.Highlights) != 2 {
+			t.Fatalf("Unexpected %s work: %+v", name, flingers)
+		}
+
+		education := resume.Education[0]
+		if education.Institution != "University of Austin" || education.Area != "B.S. Computer Science" {
+			t.Fatalf("Unexpected %s education: %+v", name, education)
+		}
+		if !reflect.DeepEqual(resume.Skills[0], data.Skill{Name: "Programming", Level: "Mid-level", Keywords: []string{"C++", "Java"}}) {
+			t.Fatalf("Unexpected %s skills: %+v", name, resume.Skills)
+		}
+		if len(resume.Publications) != 3 || len(resume.Certificates) != 1 || !reflect.DeepEqual(resume.Languages, originalData.Languages) {
+			t.Fatalf("Unexpected %s publications, certificates or languages: %+v", name, resume)
+		}
+	}
+}
+
+func TestHrOpenSchemaValidation(t *testing.T) {
+	// Exported documents must have a name
+	resume := data.ResumeData{Basics: data.Basics{Email: "peter.gibbons@initech.com"}}
+	_, err := data.ToHrOpenJson(resume, &bytes.Buffer{})
+	if schemaErr, ok := err.(data.SchemaError); !ok || len(schemaErr.Issues) != 1 ||
+		schemaErr.Issues[0] != "candidate.person.name.formattedName: must not be blank" {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Imported documents are checked before they are read
+	_, _, err = data.FromHrOpenJson(strings.NewReader(`{"candidate": {"person": {"name": {"formattedName": "Peter Gibbons"}},
+		"profiles": [{"employment": [{"organizationName": "Initech", "start": "last year", "current": "yes"}]}]}}`))
+	schemaErr, ok := err.(data.SchemaError)
+	if !ok || !reflect.DeepEqual(schemaErr.Issues, []string{
+		"candidate.profiles[0].employment[0].current: expected boolean, found a string",
+		"candidate.profiles[0].employment[0].start: \"last year\" does not match the pattern \"^[0-9]{4}(-[0-9]{2}(-[0-9]{2})?)?$\"",
+	}) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestFromHrOpenXml(t *testing.T) {
+	resume, report, err := data.FromHrOpenXml(strings.NewReader(`<?xml version="1.0" encoding="UTF-8"?>
+<Candidate xmlns="http://www.hr-xml.org/3">
+  <CandidatePerson>
+    <PersonName>
+      <FormattedName>Michael Bolton</FormattedName>
+      <PreferredName>Mike</PreferredName>
+    </PersonName>
+  </CandidatePerson>
+  <CandidateProfile>
+    <EmploymentHistory>
+      <EmployerHistory>
+        <OrganizationName>Initech</OrganizationName>
+        <PositionHistory>
+          <PositionTitle>Software Developer</PositionTitle>
+          <StartDate>1996-04</StartDate>
+          <CurrentIndicator>true</CurrentIndicator>
+          <Description>- Wrote the rounding code.</Description>
+        </PositionHistory>
+      </EmployerHistory>
+    </EmploymentHistory>
+  </CandidateProfile>
+</Candidate>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	work := resume.Work[0]
+	if resume.Basics.Name != "Michael Bolton" || work.Company != "Initech" || work.Position != "Software Developer" ||
+		work.StartDate != "1996-04" || work.EndDate != data.Present || work.Highlights[0].Text != "Wrote the rounding code." {
+		t.Fatalf("Unexpected resume data: %+v", resume)
+	}
+	expected := []data.UnmappedField{{Source: "HR Open", Field: "Candidate.CandidatePerson.PersonName.PreferredName"}}
+	if !reflect.DeepEqual(report.Unmapped, expected) {
+		t.Fatalf("Unexpected report: %v", report.Unmapped)
+	}
+}
@@ -0,0 +1,759 @@
+package data
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// jsonResumeSchemaUrl is written as the "$schema" property of canonical JSON Resume files.
+const jsonResumeSchemaUrl = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+
+// jsonResumeSchemaJson is a copy of the official JSON Resume schema, against which canonical exports are validated.
+// See "schema/jsonresume.json".
+//
+//go:embed schema/jsonresume.json
+var jsonResumeSchemaJson []byte
+
+var jsonResumeSchema = mustLoadSchema(jsonResumeSchemaJson)
+
+// isbnPattern matches the line added to a publication's summary by a canonical export.
+var isbnPattern = regexp.MustCompile(`(?:\n\n|^)ISBN: (\S+)$`)
+
+// jsonObject is a JSON object that keeps its properties in the order they were added, and leaves out empty values,
+// so that canonical exports are written in the same order as the JSON Resume schema.
+type jsonObject []jsonProperty
+
+type jsonProperty struct {
+	key   string
+	value interface{}
+}
+
+// set adds a property, unless its value is empty.
+func (object *jsonObject) set(key string, value interface{}) {
+	switch typed := value.(type) {
+	case jsonObject:
+		if len(typed) == 0 {
+			return
+		}
+	case []jsonObject:
+		if len(typed) == 0 {
+			return
+		}
+	default:
+		if value == nil || isEmptyValue(reflect.ValueOf(value)) {
+			return
+		}
+	}
+	*object = append(*object, jsonProperty{key: key, value: value})
+}
+
+// setExtra adds the extra properties of an entry, other than those already set.
+func (object *jsonObject) setExtra(extra Extra) {
+	for _, key := range sortedKeys(extra) {
+		if !object.has(key) {
+			*object = append(*object, jsonProperty{key: key, value: extra[key]})
+		}
+	}
+}
+
+func (object jsonObject) has(key string) bool {
+	for _, property := range object {
+		if property.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (object jsonObject) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for index, property := range object {
+		if index > 0 {
+			buffer.WriteByte(',')
+		}
+		keyBytes, err := json.Marshal(property.key)
+		if err != nil {
+			return nil, err
+		}
+		valueBytes, err := json.Marshal(property.value)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(keyBytes)
+		buffer.WriteByte(':')
+		buffer.Write(valueBytes)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// jsonResumeFolding records how a canonical export folded ResumeFodder's extension fields into standard JSON Resume
+// fields, so that "FromJsonResume()" can restore them.  It is written as the "resumeFodder" property of the
+// standard "meta" section.
+type jsonResumeFolding struct {
+	// Degree is the "basics.degree" value appended to "basics.label".
+	Degree string `json:"degree,omitempty"`
+	// Highlights is true if "basics.highlights" were appended to "basics.summary" as bullet points.
+	Highlights bool `json:"highlights,omitempty"`
+	// WorkGroups lists the work groups that were combined into "work", in order.
+	WorkGroups []jsonResumeGroup `json:"workGroups,omitempty"`
+	// PublicationGroups lists the publication groups that were combined into "publications", in order.
+	PublicationGroups []jsonResumeGroup `json:"publicationGroups,omitempty"`
+}
+
+// jsonResumeGroup describes a group of entries combined into a standard list.
+type jsonResumeGroup struct {
+	// Field is the ResumeData field that held the group:  "work", "additionalWork" or "workGroups" (and likewise
+	// for publications).
+	Field string `json:"field"`
+	Name  string `json:"name,omitempty"`
+	// Size is the number of entries in the group.
+	Size int `json:"size"`
+	// Roles lists the work entries in the group having roles, each of which was written as one standard entry per
+	// role.
+	Roles []jsonResumeRoles `json:"roles,omitempty"`
+}
+
+// jsonResumeRoles describes a work entry with roles, which was written as one standard entry per role.  The
+// entry's own summary was written as the "description" of the first of these, and its highlights were appended to
+// the first entry's highlights.
+type jsonResumeRoles struct {
+	// Index is the position of the work entry within its group.
+	Index      int    `json:"index"`
+	Roles      int    `json:"roles"`
+	Position   string `json:"position,omitempty"`
+	StartDate  Date   `json:"startDate,omitempty"`
+	EndDate    Date   `json:"endDate,omitempty"`
+	Highlights int    `json:"highlights,omitempty"`
+}
+
+// ToJsonResume writes resume data as a canonical JSON Resume (version 1.0) file, for use with other JSON Resume
+// tools.  ResumeFodder's extension fields are folded into their closest standard equivalents:
+//
+//   - "basics.degree" is appended to "basics.label", and "basics.highlights" to "basics.summary" as bullet points
+//   - "additionalWork" and "workGroups" are appended to "work", and "additionalPublications" and
+//     "publicationGroups" to "publications"
+//   - each role of a work entry becomes a separate work entry at the same employer
+//   - "isbn" is appended to a publication's "summary"
+//   - fields are renamed as in version 1.0 of the schema (e.g. "company" becomes "name", and "gpa" becomes "score")
+//
+// Enough detail is recorded in "meta.resumeFodder" for "FromJsonResume()" to restore the original structure.  Tags
+// can't be represented, and are listed in the returned report along with any other fields left behind.  The file
+// is validated against the official JSON Resume schema before it is written, and a "SchemaError" is returned if it
+// doesn't match.
+func ToJsonResume(data ResumeData, writer io.Writer) (MappingReport, error) {
+	document, report := toJsonResume(data)
+	jsonBytes, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return report, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(jsonBytes, &generic); err != nil {
+		return report, err
+	}
+	if err := jsonResumeSchema.validate("JSON Resume", generic); err != nil {
+		return report, err
+	}
+	_, err = writer.Write(jsonBytes)
+	return report, err
+}
+
+// FromJsonResume loads resume data from a JSON Resume file (version 1.0, or the earlier versions read by
+// "FromJsonReader()").  Any extension fields folded by "ToJsonResume()" are restored, using the details recorded in
+// "meta.resumeFodder".  The returned report lists any dates that aren't in JSON Resume format.
+func FromJsonResume(reader io.Reader) (ResumeData, MappingReport, error) {
+	report := MappingReport{}
+	var document map[string]interface{}
+	if err := json.NewDecoder(reader).Decode(&document); err != nil {
+		return ResumeData{}, report, err
+	}
+	delete(document, "$schema")
+	folding := jsonResumeFolding{}
+	if meta, ok := document["meta"].(map[string]interface{}); ok {
+		if recorded, ok := meta["resumeFodder"]; ok {
+			recordedBytes, err := json.Marshal(recorded)
+			if err != nil {
+				return ResumeData{}, report, err
+			}
+			if err := json.Unmarshal(recordedBytes, &folding); err != nil {
+				return ResumeData{}, report, fmt.Errorf("Could not read meta.resumeFodder: %s", err)
+			}
+			delete(meta, "resumeFodder")
+			if len(meta) == 0 {
+				delete(document, "meta")
+			}
+		}
+	}
+
+	// Rename the fields that changed in version 1.0 of the schema
+	if basics, ok := document["basics"].(map[string]interface{}); ok {
+		renameProperty(basics, "image", "picture")
+		renameProperty(basics, "url", "website")
+	}
+	renameListProperty(document["work"], "name", "company")
+	renameListProperty(document["work"], "url", "website")
+	renameListProperty(document["education"], "score", "gpa")
+	renameListProperty(document["publications"], "url", "website")
+
+	jsonBytes, err := json.Marshal(document)
+	if err != nil {
+		return ResumeData{}, report, err
+	}
+	data, err := FromJsonReader(bytes.NewReader(jsonBytes))
+	if err != nil {
+		return ResumeData{}, report, err
+	}
+	data.Version = SCHEMA_VERSION
+	restoreJsonResume(&data, folding)
+	checkJsonResumeDates(data, &report)
+	return data, report, nil
+}
+
+// renameProperty moves a property to a new name, unless a property with the new name is already present.
+func renameProperty(object map[string]interface{}, from, to string) {
+	if value, ok := object[from]; ok {
+		if _, exists := object[to]; !exists {
+			object[to] = value
+			delete(object, from)
+		}
+	}
+}
+
+// renameListProperty applies "renameProperty()" to each object in a list.
+func renameListProperty(list interface{}, from, to string) {
+	items, _ := list.([]interface{})
+	for _, item := range items {
+		if object, ok := item.(map[string]interface{}); ok {
+			renameProperty(object, from, to)
+		}
+	}
+}
+
+// toJsonResume builds a canonical JSON Resume document from resume data, reporting any non-empty fields that are
+// left behind.
+func toJsonResume(data ResumeData) (jsonObject, MappingReport) {
+	report := MappingReport{}
+	unmapped := func(source, field string, value interface{}) {
+		if !isEmptyValue(reflect.ValueOf(value)) {
+			report.addUnmapped(source, field)
+		}
+	}
+	date := func(date Date) string {
+		if date.IsBlank() || date.IsPresent() {
+			return ""
+		} else if date.Precision() != PrecisionNone {
+			return string(date)
+		} else if normalized, ok := NormalizeDate(string(date)); ok {
+			return string(normalized)
+		}
+		report.Notes = append(report.Notes, fmt.Sprintf("JSON Resume: unrecognized date \"%s\" was left out", date))
+		return ""
+	}
+	folding := jsonResumeFolding{}
+	document := jsonObject{{key: "$schema", value: jsonResumeSchemaUrl}}
+
+	// Basics
+	basics := data.Basics
+	basicsObject := jsonObject{}
+	basicsObject.set("name", basics.Name)
+	label := basics.Label
+	if basics.Degree != "" {
+		folding.Degree = basics.Degree
+		label = strings.TrimPrefix(label+", "+basics.Degree, ", ")
+	}
+	basicsObject.set("label", label)
+	basicsObject.set("image", basics.Picture)
+	basicsObject.set("email", basics.Email)
+	basicsObject.set("phone", basics.Phone)
+	basicsObject.set("url", basics.Website)
+	folding.Highlights = len(basics.Highlights) > 0
+	basicsObject.set("summary", joinBullets(basics.Summary, basics.Highlights))
+	location := jsonObject{}
+	location.set("address", basics.Location.Address)
+	location.set("postalCode", basics.Location.PostalCode)
+	location.set("city", basics.Location.City)
+	location.set("countryCode", basics.Location.CountryCode)
+	location.set("region", basics.Location.Region)
+	location.setExtra(basics.Location.Extra)
+	basicsObject.set("location", location)
+	var profiles []jsonObject
+	for _, profile := range basics.Profiles {
+		profileObject := jsonObject{}
+		profileObject.set("network", profile.Network)
+		profileObject.set("username", profile.Username)
+		profileObject.set("url", profile.Url)
+		profileObject.setExtra(profile.Extra)
+		profiles = append(profiles, profileObject)
+	}
+	basicsObject.set("profiles", profiles)
+	basicsObject.setExtra(basics.Extra)
+	document.set("basics", basicsObject)
+
+	// Work, with every group combined and each role written as a separate entry
+	var work []jsonObject
+	type workSource struct {
+		field string
+		group WorkGroup
+	}
+	workGroups := []workSource{
+		{"work", WorkGroup{Name: data.WorkLabel, Work: data.Work}},
+		{"additionalWork", WorkGroup{Name: data.AdditionalWorkLabel, Work: data.AdditionalWork}},
+	}
+	for _, group := range data.WorkGroups {
+		workGroups = append(workGroups, workSource{"workGroups", group})
+	}
+	for _, entry := range workGroups {
+		if entry.field != "workGroups" && len(entry.group.Work) == 0 {
+			unmapped(entry.field+"Label", entry.field+"Label", entry.group.Name)
+			continue
+		}
+		groupFolding := jsonResumeGroup{Field: entry.field, Name: entry.group.Name, Size: len(entry.group.Work)}
+		for index, job := range entry.group.Work {
+			unmapped("work", "tags", job.Tags)
+			if len(job.Roles) == 0 {
+				workObject := jsonObject{}
+				workObject.set("name", job.Company)
+				workObject.set("position", job.Position)
+				workObject.set("url", job.Website)
+				workObject.set("startDate", date(job.StartDate))
+				workObject.set("endDate", date(job.EndDate))
+				workObject.set("summary", job.Summary)
+				workObject.set("highlights", jsonResumeHighlights(job.Highlights, &report))
+				workObject.setExtra(job.Extra)
+				work = append(work, workObject)
+				continue
+			}
+			groupFolding.Roles = append(groupFolding.Roles, jsonResumeRoles{
+				Index:      index,
+				Roles:      len(job.Roles),
+				Position:   job.Position,
+				StartDate:  job.StartDate,
+				EndDate:    job.EndDate,
+				Highlights: len(job.Highlights),
+			})
+			for roleIndex, role := range job.Roles {
+				workObject := jsonObject{}
+				workObject.set("name", job.Company)
+				highlights := role.Highlights
+				if roleIndex == 0 {
+					workObject.set("description", job.Summary)
+					highlights = append(append([]Highlight{}, highlights...), job.Highlights...)
+				}
+				workObject.set("position", role.Title)
+				workObject.set("url", job.Website)
+				workObject.set("startDate", date(role.StartDate))
+				workObject.set("endDate", date(role.EndDate))
+				workObject.set("summary", role.Summary)
+				workObject.set("highlights", jsonResumeHighlights(highlights, &report))
+				workObject.setExtra(role.Extra)
+				if roleIndex == 0 {
+					workObject.setExtra(job.Extra)
+				}
+				work = append(work, workObject)
+			}
+		}
+		folding.WorkGroups = append(folding.WorkGroups, groupFolding)
+	}
+	if len(folding.WorkGroups) == 1 && folding.WorkGroups[0].Field == "work" && folding.WorkGroups[0].Name == "" &&
+		len(folding.WorkGroups[0].Roles) == 0 {
+		// Nothing to restore
+		folding.WorkGroups = nil
+	}
+	document.set("work", work)
+
+	var volunteer []jsonObject
+	for _, entry := range data.Volunteer {
+		object := jsonObject{}
+		object.set("organization", entry.Organization)
+		object.set("position", entry.Position)
+		object.set("url", entry.Url)
+		object.set("startDate", date(entry.StartDate))
+		object.set("endDate", date(entry.EndDate))
+		object.set("summary", entry.Summary)
+		object.set("highlights", entry.Highlights)
+		object.setExtra(entry.Extra)
+		volunteer = append(volunteer, object)
+	}
+	document.set("volunteer", volunteer)
+
+	var education []jsonObject
+	for _, entry := range data.Education {
+		object := jsonObject{}
+		object.set("institution", entry.Institution)
+		object.set("area", entry.Area)
+		object.set("studyType", entry.StudyType)
+		object.set("startDate", date(entry.StartDate))
+		object.set("endDate", date(entry.EndDate))
+		object.set("score", entry.GPA)
+		object.set("courses", entry.Courses)
+		object.setExtra(entry.Extra)
+		education = append(education, object)
+		unmapped("education", "tags", entry.Tags)
+	}
+	document.set("education", education)
+
+	var awards []jsonObject
+	for _, entry := range data.Awards {
+		object := jsonObject{}
+		object.set("title", entry.Title)
+		object.set("date", date(entry.Date))
+		object.set("awarder", entry.Awarder)
+		object.set("summary", entry.Summary)
+		object.setExtra(entry.Extra)
+		awards = append(awards, object)
+	}
+	document.set("awards", awards)
+
+	var certificates []jsonObject
+	for _, entry := range data.Certificates {
+		object := jsonObject{}
+		object.set("name", entry.Name)
+		object.set("date", date(entry.Date))
+		object.set("issuer", entry.Issuer)
+		object.set("url", entry.Url)
+		object.setExtra(entry.Extra)
+		certificates = append(certificates, object)
+	}
+	document.set("certificates", certificates)
+
+	// Publications, with every group combined
+	var publications []jsonObject
+	type publicationSource struct {
+		field string
+		group PublicationGroup
+	}
+	publicationGroups := []publicationSource{
+		{"publications", PublicationGroup{Name: data.PublicationsLabel, Publications: data.Publications}},
+		{"additionalPublications", PublicationGroup{Name: data.AdditionalPublicationsLabel, Publications: data.AdditionalPublications}},
+	}
+	for _, group := range data.PublicationGroups {
+		publicationGroups = append(publicationGroups, publicationSource{"publicationGroups", group})
+	}
+	for _, entry := range publicationGroups {
+		if entry.field != "publicationGroups" && len(entry.group.Publications) == 0 {
+			unmapped(entry.field+"Label", entry.field+"Label", entry.group.Name)
+			continue
+		}
+		folding.PublicationGroups = append(folding.PublicationGroups,
+			jsonResumeGroup{Field: entry.field, Name: entry.group.Name, Size: len(entry.group.Publications)})
+		for _, publication := range entry.group.Publications {
+			object := jsonObject{}
+			object.set("name", publication.Name)
+			object.set("publisher", publication.Publisher)
+			object.set("releaseDate", date(publication.ReleaseDate))
+			object.set("url", publication.Website)
+			summary := publication.Summary
+			if publication.ISBN != "" {
+				summary = strings.TrimPrefix(summary+"\n\nISBN: "+publication.ISBN, "\n\n")
+			}
+			object.set("summary", summary)
+			object.setExtra(publication.Extra)
+			publications = append(publications, object)
+			unmapped("publications", "tags", publication.Tags)
+		}
+	}
+	if len(folding.PublicationGroups) == 1 && folding.PublicationGroups[0].Field == "publications" &&
+		folding.PublicationGroups[0].Name == "" {
+		folding.PublicationGroups = nil
+	}
+	document.set("publications", publications)
+
+	var skills []jsonObject
+	for _, entry := range data.Skills {
+		object := jsonObject{}
+		object.set("name", entry.Name)
+		object.set("level", entry.Level)
+		object.set("keywords", entry.Keywords)
+		object.setExtra(entry.Extra)
+		skills = append(skills, object)
+		unmapped("skills", "tags", entry.Tags)
+	}
+	document.set("skills", skills)
+
+	var languages []jsonObject
+	for _, entry := range data.Languages {
+		object := jsonObject{}
+		object.set("language", entry.Language)
+		object.set("fluency", entry.Fluency)
+		object.setExtra(entry.Extra)
+		languages = append(languages, object)
+	}
+	document.set("languages", languages)
+
+	var interests []jsonObject
+	for _, entry := range data.Interests {
+		object := jsonObject{}
+		object.set("name", entry.Name)
+		object.set("keywords", entry.Keywords)
+		object.setExtra(entry.Extra)
+		interests = append(interests, object)
+	}
+	document.set("interests", interests)
+
+	var references []jsonObject
+	for _, entry := range data.References {
+		object := jsonObject{}
+		object.set("name", entry.Name)
+		object.set("reference", entry.Reference)
+		object.setExtra(entry.Extra)
+		references = append(references, object)
+	}
+	document.set("references", references)
+
+	var projects []jsonObject
+	for _, entry := range data.Projects {
+		object := jsonObject{}
+		object.set("name", entry.Name)
+		object.set("description", entry.Description)
+		object.set("highlights", entry.Highlights)
+		object.set("keywords", entry.Keywords)
+		object.set("startDate", date(entry.StartDate))
+		object.set("endDate", date(entry.EndDate))
+		object.set("url", entry.Url)
+		object.set("roles", entry.Roles)
+		object.set("entity", entry.Entity)
+		object.set("type", entry.Type)
+		object.setExtra(entry.Extra)
+		projects = append(projects, object)
+	}
+	document.set("projects", projects)
+
+	// The standard "meta" section, with the folding details added.  No other top-level extras are allowed.
+	meta := jsonObject{}
+	if original, ok := data.Extra["meta"].(map[string]interface{}); ok {
+		meta.setExtra(original)
+	}
+	if !isEmptyValue(reflect.ValueOf(folding)) {
+		meta.set("resumeFodder", folding)
+	}
+	document.set("meta", meta)
+	for _, key := range sortedKeys(data.Extra) {
+		if key != "meta" && key != "$schema" {
+			unmapped("resume", key, data.Extra[key])
+		}
+	}
+	return document, report
+}
+
+// jsonResumeHighlights returns highlights as plain strings, reporting any tags that are left behind.
+func jsonResumeHighlights(highlights []Highlight, report *MappingReport) []string {
+	for _, highlight := range highlights {
+		if len(highlight.Tags) > 0 {
+			report.addUnmapped("work", "highlights.tags")
+		}
+	}
+	return highlightTexts(highlights)
+}
+
+// restoreJsonResume reverses the folding made by "toJsonResume()", as recorded in "meta.resumeFodder".
+func restoreJsonResume(data *ResumeData, folding jsonResumeFolding) {
+	basics := &data.Basics
+	if folding.Degree != "" {
+		basics.Degree = folding.Degree
+		if basics.Label == folding.Degree {
+			basics.Label = ""
+		} else {
+			basics.Label = strings.TrimSuffix(basics.Label, ", "+folding.Degree)
+		}
+	}
+	if folding.Highlights {
+		basics.Summary, basics.Highlights = splitBullets(basics.Summary)
+	}
+
+	for index := range data.Publications {
+		publication := &data.Publications[index]
+		if match := isbnPattern.FindStringSubmatch(publication.Summary); match != nil {
+			publication.ISBN = match[1]
+			publication.Summary = strings.TrimSuffix(publication.Summary, match[0])
+		}
+	}
+
+	if len(folding.WorkGroups) > 0 {
+		remaining := data.Work
+		data.Work = nil
+		for _, group := range folding.WorkGroups {
+			var work []Work
+			for index := 0; index < group.Size && len(remaining) > 0; index++ {
+				roles := jsonResumeRoles{}
+				for _, candidate := range group.Roles {
+					if candidate.Index == index {
+						roles = candidate
+					}
+				}
+				if roles.Roles == 0 {
+					work = append(work, remaining[0])
+					remaining = remaining[1:]
+					continue
+				}
+				count := roles.Roles
+				if count > len(remaining) {
+					count = len(remaining)
+				}
+				work = append(work, restoreJsonResumeRoles(remaining[:count], roles))
+				remaining = remaining[count:]
+			}
+			switch group.Field {
+			case "work":
+				data.Work, data.WorkLabel = work, group.Name
+			case "additionalWork":
+				data.AdditionalWork, data.AdditionalWorkLabel = work, group.Name
+			default:
+				data.WorkGroups = append(data.WorkGroups, WorkGroup{Name: group.Name, Work: work})
+			}
+		}
+		// Any entries beyond those recorded (e.g. added by another tool) stay in the main list
+		data.Work = append(data.Work, remaining...)
+	}
+
+	if len(folding.PublicationGroups) > 0 {
+		remaining := data.Publications
+		data.Publications = nil
+		for _, group := range folding.PublicationGroups {
+			count := group.Size
+			if count > len(remaining) {
+				count = len(remaining)
+			}
+			publications := remaining[:count]
+			remaining = remaining[count:]
+			switch group.Field {
+			case "publications":
+				data.Publications, data.PublicationsLabel = publications, group.Name
+			case "additionalPublications":
+				data.AdditionalPublications, data.AdditionalPublicationsLabel = publications, group.Name
+			default:
+				data.PublicationGroups = append(data.PublicationGroups, PublicationGroup{Name: group.Name, Publications: publications})
+			}
+		}
+		data.Publications = append(data.Publications, remaining...)
+	}
+}
+
+// restoreJsonResumeRoles combines the standard work entries written for each role back into a single entry.
+func restoreJsonResumeRoles(entries []Work, roles jsonResumeRoles) Work {
+	first := entries[0]
+	work := Work{
+		Company:   first.Company,
+		Position:  roles.Position,
+		Website:   first.Website,
+		StartDate: roles.StartDate,
+		EndDate:   roles.EndDate,
+	}
+	for index, entry := range entries {
+		role := Role{
+			Title:      entry.Position,
+			StartDate:  entry.StartDate,
+			EndDate:    entry.EndDate,
+			Summary:    entry.Summary,
+			Highlights: entry.Highlights,
+		}
+		if index == 0 {
+			if description, ok := entry.Extra["description"].(string); ok {
+				work.Summary = description
+			}
+			split := len(role.Highlights) - roles.Highlights
+			if split < 0 {
+				split = 0
+			}
+			role.Highlights, work.Highlights = role.Highlights[:split], role.Highlights[split:]
+			if len(role.Highlights) == 0 {
+				role.Highlights = nil
+			}
+			if len(work.Highlights) == 0 {
+				work.Highlights = nil
+			}
+			for key, value := range entry.Extra {
+				if key == "description" {
+					continue
+				}
+				if work.Extra == nil {
+					work.Extra = Extra{}
+				}
+				work.Extra[key] = value
+			}
+		} else {
+			role.Extra = entry.Extra
+		}
+		work.Roles = append(work.Roles, role)
+	}
+	return work
+}
+
+// checkJsonResumeDates notes any dates that aren't in the format required by JSON Resume (and ResumeFodder's
+// date functions).
+func checkJsonResumeDates(data ResumeData, report *MappingReport) {
+	check := func(section string, dates ...Date) {
+		for _, date := range dates {
+			if !date.Valid() {
+				report.Notes = append(report.Notes, fmt.Sprintf("%s: unrecognized date \"%s\"", section, date))
+			}
+		}
+	}
+	for _, group := range data.AllWorkGroups() {
+		for _, work := range group.Work {
+			check("work", work.StartDate, work.EndDate)
+			for _, role := range work.Roles {
+				check("work", role.StartDate, role.EndDate)
+			}
+		}
+	}
+	for _, volunteer := range data.Volunteer {
+		check("volunteer", volunteer.StartDate, volunteer.EndDate)
+	}
+	for _, education := range data.Education {
+		check("education", education.StartDate, education.EndDate)
+	}
+}
+
+func init() {
+	RegisterCodec(jsonResumeCodec{})
+}
+
+// jsonResumeCodec is the canonical JSON Resume format (see "ToJsonResume()").  Files are recognized by the
+// ".resume.json" extension, or by a "$schema" property at the start of the top-level object whose value is a JSON
+// Resume schema URL (on "jsonresume.org", or in the "jsonresume/resume-schema" repository).  Native JSON files may
+// begin with a "$schema" property of their own, naming some other schema.
+type jsonResumeCodec struct{}
+
+func (jsonResumeCodec) Name() string {
+	return "jsonresume"
+}
+
+func (jsonResumeCodec) Extensions() []string {
+	return []string{".resume.json"}
+}
+
+func (jsonResumeCodec) MimeType() string {
+	return "application/json"
+}
+
+func (codec jsonResumeCodec) Decode(reader io.Reader) (ResumeData, error) {
+	data, _, err := codec.DecodeWithReport(reader)
+	return data, err
+}
+
+func (codec jsonResumeCodec) Encode(data ResumeData, writer io.Writer) error {
+	_, err := codec.EncodeWithReport(data, writer)
+	return err
+}
+
+func (jsonResumeCodec) DecodeWithReport(reader io.Reader) (ResumeData, MappingReport, error) {
+	return FromJsonResume(reader)
+}
+
+func (jsonResumeCodec) EncodeWithReport(data ResumeData, writer io.Writer) (MappingReport, error) {
+	return ToJsonResume(data, writer)
+}
+
+func (jsonResumeCodec) Detect(head []byte) bool {
+	key, value := jsonFirstProperty(head)
+	value = strings.ToLower(value)
+	return key == "$schema" &&
+		(strings.Contains(value, "jsonresume.org") || strings.Contains(value, "/jsonresume/resume-schema"))
+}
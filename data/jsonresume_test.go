@@ -0,0 +1,110 @@
+package data_test
+
+import (
+	"bytes"
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJsonResumeConversion(t *testing.T) {
+	originalData := testutils.GenerateTestResumeData()
+	originalData.Basics.Degree = "B.S."
+
+	var buffer bytes.Buffer
+	report, err := data.ToJsonResume(originalData, &buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []data.UnmappedField{
+		{Source: "work", Field: "tags"},
+		{Source: "work", Field: "highlights.tags"},
+		{Source: "skills", Field: "tags"},
+	}
+	if !reflect.DeepEqual(report.Unmapped, expected) {
+		t.Fatalf("Unexpected report: %v", report.Unmapped)
+	}
+	jsonResume := buffer.String()
+	for _, expected := range []string{`"$schema": "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"`,
+		`"name": "Initech"`, `"label": "B.S."`, `"position": "Senior Software Developer"`, `"name": "Flingers"`,
+		`"name": "The Bobs"`, "ISBN: 1234567890X", `"resumeFodder"`} {
+		if !strings.Contains(jsonResume, expected) {
+			t.Fatalf("Expected %s in canonical JSON Resume:\n%s", expected, jsonResume)
+		}
+	}
+	for _, unexpected := range []string{`"company"`, `"additionalWork": [`, `"isbn"`, `"workLabel"`, `"tags"`} {
+		if strings.Contains(jsonResume, unexpected) {
+			t.Fatalf("Unexpected %s in canonical JSON Resume:\n%s", unexpected, jsonResume)
+		}
+	}
+
+	// Everything but the tags is restored
+	fromJsonResume, report, err := data.FromJsonResume(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Notes) > 0 {
+		t.Fatalf("Unexpected notes: %v", report.Notes)
+	}
+	if !reflect.DeepEqual(withoutTags(originalData), fromJsonResume) {
+		t.Fatalf("Resume data after JSON Resume conversion doesn't match the original:\n%+v\n%+v", withoutTags(originalData), fromJsonResume)
+	}
+}
+
+func TestFromJsonResume_Standard(t *testing.T) {
+	resume, report, err := data.FromJsonResume(strings.NewReader(`{
+  "basics": {"name": "Peter Gibbons", "image": "peter.jpg", "url": "https://example.com"},
+  "work": [{"name": "Initech", "url": "https://initech.com", "location": "Austin, TX", "startDate": "Feb 1998"}],
+  "education": [{"institution": "University of Austin", "score": "3.2"}],
+  "meta": {"version": "v1.0.0"}
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resume.Basics.Picture != "peter.jpg" || resume.Basics.Website != "https://example.com" {
+		t.Fatalf("Unexpected basics: %+v", resume.Basics)
+	}
+	work := resume.Work[0]
+	if work.Company != "Initech" || work.Website != "https://initech.com" || work.Extra["location"] != "Austin, TX" {
+		t.Fatalf("Unexpected work: %+v", work)
+	}
+	if resume.Education[0].GPA != "3.2" || resume.Extra.Get("meta.version") != "v1.0.0" {
+		t.Fatalf("Unexpected resume data: %+v", resume)
+	}
+	if !reflect.DeepEqual(report.Notes, []string{"work: unrecognized date \"Feb 1998\""}) {
+		t.Fatalf("Unexpected notes: %v", report.Notes)
+	}
+}
+
+func TestToJsonResume_SchemaError(t *testing.T) {
+	resume := data.ResumeData{Basics: data.Basics{Extra: data.Extra{"name": 5.0}}}
+	_, err := data.ToJsonResume(resume, &bytes.Buffer{})
+	if schemaErr, ok := err.(data.SchemaError); !ok || !reflect.DeepEqual(schemaErr.Issues, []string{"basics.name: expected string, found a number"}) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+// withoutTags returns a copy of resume data with every tag removed.
+func withoutTags(resume data.ResumeData) data.ResumeData {
+	jsonString, _ := data.ToJsonString(resume)
+	copied, _ := data.FromJsonString(jsonString)
+	for _, work := range [][]data.Work{copied.Work, copied.AdditionalWork} {
+		for index := range work {
+			work[index].Tags = nil
+			for highlightIndex := range work[index].Highlights {
+				work[index].Highlights[highlightIndex].Tags = nil
+			}
+			for roleIndex := range work[index].Roles {
+				for highlightIndex := range work[index].Roles[roleIndex].Highlights {
+					work[index].Roles[roleIndex].Highlights[highlightIndex].Tags = nil
+				}
+			}
+		}
+	}
+	for index := range copied.Skills {
+		copied.Skills[index].Tags = nil
+	}
+	return copied
+}
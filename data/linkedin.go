@@ -0,0 +1,310 @@
+package data
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// linkedInRow is a single row of a CSV file from a LinkedIn data export, keyed by column name.
+type linkedInRow struct {
+	file   string
+	values map[string]string
+	report *MappingReport
+}
+
+// text returns the trimmed value of a column.
+func (row linkedInRow) text(column string) string {
+	return strings.TrimSpace(row.values[column])
+}
+
+// date returns the value of a column as a JSON-Resume date, noting any value that isn't in a recognized format.
+func (row linkedInRow) date(column string) Date {
+	date, ok := NormalizeDate(row.values[column])
+	if !ok {
+		row.report.Notes = append(row.report.Notes,
+			fmt.Sprintf("%s: unrecognized date \"%s\" in column \"%s\"", row.file, date, column))
+	}
+	return date
+}
+
+// linkedInImporter maps the columns of one CSV file from a LinkedIn data export onto resume data.
+type linkedInImporter struct {
+	columns []string
+	apply   func(data *ResumeData, row linkedInRow)
+}
+
+// linkedInImporters lists the CSV files that are read from a LinkedIn data export, keyed by filename.  Every other
+// file in the archive is ignored.
+var linkedInImporters = map[string]linkedInImporter{
+	"Profile.csv": {
+		columns: []string{"First Name", "Last Name", "Headline", "Summary", "Address", "Zip Code", "Geo Location",
+			"Websites", "Twitter Handles"},
+		apply: func(data *ResumeData, row linkedInRow) {
+			data.Basics.Name = strings.TrimSpace(row.text("First Name") + " " + row.text("Last Name"))
+			data.Basics.Label = row.text("Headline")
+			data.Basics.Summary = row.text("Summary")
+			data.Basics.Location.Address = row.text("Address")
+			data.Basics.Location.PostalCode = row.text("Zip Code")
+			// e.g. "Austin, Texas, United States"
+			geo := strings.Split(row.text("Geo Location"), ",")
+			data.Basics.Location.City = strings.TrimSpace(geo[0])
+			if len(geo) > 1 {
+				data.Basics.Location.Region = strings.TrimSpace(geo[1])
+			}
+			// e.g. "[PORTFOLIO:https://example.com,BLOG:https://blog.example.com]"
+			for _, website := range linkedInList(row.text("Websites")) {
+				network, url := "Website", website
+				if index := strings.Index(website, ":"); index > 0 && !strings.HasPrefix(website[index:], "://") {
+					label := strings.ToLower(website[:index])
+					network, url = strings.ToUpper(label[:1])+label[1:], website[index+1:]
+				}
+				if data.Basics.Website == "" {
+					data.Basics.Website = url
+				} else {
+					data.Basics.Profiles = append(data.Basics.Profiles, SocialProfile{Network: network, Url: url})
+				}
+			}
+			for _, handle := range linkedInList(row.text("Twitter Handles")) {
+				handle = strings.TrimPrefix(handle, "@")
+				data.Basics.Profiles = append(data.Basics.Profiles,
+					SocialProfile{Network: "Twitter", Username: handle, Url: "https://twitter.com/" + handle})
+			}
+		},
+	},
+	"Email Addresses.csv": {
+		columns: []string{"Email Address", "Primary"},
+		apply: func(data *ResumeData, row linkedInRow) {
+			if data.Basics.Email == "" || strings.EqualFold(row.text("Primary"), "Yes") {
+				data.Basics.Email = row.text("Email Address")
+			}
+		},
+	},
+	"PhoneNumbers.csv": {
+		columns: []string{"Number"},
+		apply: func(data *ResumeData, row linkedInRow) {
+			if data.Basics.Phone == "" {
+				data.Basics.Phone = row.text("Number")
+			}
+		},
+	},
+	"Positions.csv": {
+		columns: []string{"Company Name", "Title", "Description", "Location", "Started On", "Finished On"},
+		apply: func(data *ResumeData, row linkedInRow) {
+			work := Work{
+				Company:   row.text("Company Name"),
+				Position:  row.text("Title"),
+				Summary:   row.text("Description"),
+				StartDate: row.date("Started On"),
+				EndDate:   row.date("Finished On"),
+			}
+			if location := row.text("Location"); location != "" {
+				work.Extra = Extra{"location": location}
+			}
+			data.Work = append(data.Work, work)
+		},
+	},
+	"Education.csv": {
+		columns: []string{"School Name", "Degree Name", "Field Of Study", "Start Date", "End Date"},
+		apply: func(data *ResumeData, row linkedInRow) {
+			data.Education = append(data.Education, Education{
+				Institution: row.text("School Name"),
+				StudyType:   row.text("Degree Name"),
+				Area:        row.text("Field Of Study"),
+				StartDate:   row.date("Start Date"),
+				EndDate:     row.date("End Date"),
+			})
+		},
+	},
+	"Skills.csv": {
+		columns: []string{"Name"},
+		apply: func(data *ResumeData, row linkedInRow) {
+			data.Skills = append(data.Skills, Skill{Name: row.text("Name")})
+		},
+	},
+	"Publications.csv": {
+		columns: []string{"Name", "Published On", "Description", "Publisher", "Url"},
+		apply: func(data *ResumeData, row linkedInRow) {
+			data.Publications = append(data.Publications, Publication{
+				Name:        row.text("Name"),
+				Publisher:   row.text("Publisher"),
+				ReleaseDate: row.date("Published On"),
+				Website:     row.text("Url"),
+				Summary:     row.text("Description"),
+			})
+		},
+	},
+	"Certifications.csv": {
+		columns: []string{"Name", "Url", "Authority", "Started On"},
+		apply: func(data *ResumeData, row linkedInRow) {
+			data.Certificates = append(data.Certificates, Certificate{
+				Name:   row.text("Name"),
+				Date:   row.date("Started On"),
+				Issuer: row.text("Authority"),
+				Url:    row.text("Url"),
+			})
+		},
+	},
+	"Languages.csv": {
+		columns: []string{"Name", "Proficiency"},
+		apply: func(data *ResumeData, row linkedInRow) {
+			data.Languages = append(data.Languages, Language{Language: row.text("Name"), Fluency: row.text("Proficiency")})
+		},
+	},
+	"Projects.csv": {
+		columns: []string{"Title", "Description", "Url", "Started On", "Finished On"},
+		apply: func(data *ResumeData, row linkedInRow) {
+			data.Projects = append(data.Projects, Project{
+				Name:        row.text("Title"),
+				Description: row.text("Description"),
+				Url:         row.text("Url"),
+				StartDate:   row.date("Started On"),
+				EndDate:     row.date("Finished On"),
+			})
+		},
+	},
+	"Honors.csv": {
+		columns: []string{"Title", "Description", "Issued On"},
+		apply: func(data *ResumeData, row linkedInRow) {
+			data.Awards = append(data.Awards, Award{
+				Title:   row.text("Title"),
+				Summary: row.text("Description"),
+				Date:    row.date("Issued On"),
+			})
+		},
+	},
+	"Volunteering.csv": {
+		columns: []string{"Company Name", "Role", "Description", "Started On", "Finished On"},
+		apply: func(data *ResumeData, row linkedInRow) {
+			data.Volunteer = append(data.Volunteer, Volunteer{
+				Organization: row.text("Company Name"),
+				Position:     row.text("Role"),
+				Summary:      row.text("Description"),
+				StartDate:    row.date("Started On"),
+				EndDate:      row.date("Finished On"),
+			})
+		},
+	},
+}
+
+// FromLinkedInFile imports resume data from the zip archive produced by LinkedIn's "download your data" feature.
+// Dates are converted to JSON-Resume format (e.g. "Feb 2019" becomes "2019-02"), and the returned report lists
+// every CSV column that had no place in the resume data, along with the files that weren't read at all.
+//
+// The following files are read, when present:  Profile.csv, Email Addresses.csv, PhoneNumbers.csv, Positions.csv,
+// Education.csv, Skills.csv, Publications.csv, Certifications.csv, Languages.csv, Projects.csv, Honors.csv and
+// Volunteering.csv.
+func FromLinkedInFile(zipFilename string) (ResumeData, MappingReport, error) {
+	archive, err := zip.OpenReader(zipFilename)
+	if err != nil {
+		return ResumeData{}, MappingReport{}, err
+	}
+	defer archive.Close()
+	return fromLinkedIn(&archive.Reader)
+}
+
+// FromLinkedInReader is a variant of "FromLinkedInFile()", which reads the zip archive from memory or another
+// random-access source (e.g. an uploaded file).
+func FromLinkedInReader(reader io.ReaderAt, size int64) (ResumeData, MappingReport, error) {
+	archive, err := zip.NewReader(reader, size)
+	if err != nil {
+		return ResumeData{}, MappingReport{}, err
+	}
+	return fromLinkedIn(archive)
+}
+
+func fromLinkedIn(archive *zip.Reader) (ResumeData, MappingReport, error) {
+	data := ResumeData{Version: SCHEMA_VERSION}
+	report := MappingReport{}
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		name := path.Base(file.Name)
+		importer, ok := linkedInImporters[name]
+		if !ok {
+			report.Ignored = append(report.Ignored, file.Name)
+			continue
+		}
+		if err := importLinkedInFile(file, name, importer, &data, &report); err != nil {
+			return ResumeData{}, report, fmt.Errorf("Could not read %s: %s", file.Name, err)
+		}
+	}
+	return data, report, nil
+}
+
+// importLinkedInFile applies an importer to each row of a CSV file within the archive.
+func importLinkedInFile(file *zip.File, name string, importer linkedInImporter, data *ResumeData, report *MappingReport) error {
+	contents, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer contents.Close()
+	reader := csv.NewReader(contents)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+
+	// Some exported files begin with a few lines of notes, so the header is the first row naming a known column
+	known := map[string]bool{}
+	for _, column := range importer.columns {
+		known[column] = true
+	}
+	headerIndex := -1
+	for index, record := range records {
+		for column := range record {
+			record[column] = strings.TrimSpace(strings.TrimPrefix(record[column], "\ufeff"))
+			if known[record[column]] && headerIndex < 0 {
+				headerIndex = index
+			}
+		}
+		if headerIndex >= 0 {
+			break
+		}
+	}
+	if headerIndex < 0 {
+		report.Ignored = append(report.Ignored, file.Name)
+		return nil
+	}
+	header := records[headerIndex]
+	for _, column := range header {
+		if !known[column] && column != "" {
+			report.addUnmapped(name, column)
+		}
+	}
+
+	for _, record := range records[headerIndex+1:] {
+		row := linkedInRow{file: name, values: map[string]string{}, report: report}
+		blank := true
+		for index, value := range record {
+			if index < len(header) {
+				row.values[header[index]] = value
+			}
+			if strings.TrimSpace(value) != "" {
+				blank = false
+			}
+		}
+		if !blank {
+			importer.apply(data, row)
+		}
+	}
+	return nil
+}
+
+// linkedInList splits a bracketed, comma-separated list (e.g. "[first,second]") as found in some exported columns.
+func linkedInList(text string) []string {
+	text = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(text), "["), "]")
+	var list []string
+	for _, item := range strings.Split(text, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
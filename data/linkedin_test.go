@@ -0,0 +1,134 @@
+package data_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"reflect"
+	"testing"
+)
+
+// linkedInArchive builds an in-memory zip archive laid out like a LinkedIn data export.
+func linkedInArchive(t *testing.T, files map[string]string) *bytes.Reader {
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for name, contents := range files {
+		file, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := file.Write([]byte(contents)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buffer.Bytes())
+}
+
+func TestFromLinkedInReader(t *testing.T) {
+	archive := linkedInArchive(t, map[string]string{
+		"Profile.csv": "\ufeffFirst Name,Last Name,Maiden Name,Address,Birth Date,Headline,Summary,Industry,Zip Code,Geo Location,Twitter Handles,Websites,Instant Messengers\n" +
+			"Peter,Gibbons,,,,Software Developer,\"Fixes the Y2K bug, mostly\",Computer Software,55555,\"Austin, Texas, United States\",[pgibbons],[PERSONAL:https://peter.example.com],\n",
+		"Email Addresses.csv": "Email Address,Confirmed,Primary,Updated On\n" +
+			"old@example.com,Yes,No,1/1/99\n" +
+			"peter.gibbons@initech.com,Yes,Yes,1/1/99\n",
+		"PhoneNumbers.csv": "Extension,Number,Type\n,555-555-5555,Mobile\n",
+		"Positions.csv": "Company Name,Title,Description,Location,Started On,Finished On\n" +
+			"Initech,Software Developer,Updated bank software for the 2000 switch,\"Austin, Texas\",Feb 1998,\n" +
+			"Flingers,Waiter,,,1996,Sometime in 1997\n",
+		"Education.csv": "School Name,Start Date,End Date,Notes,Degree Name,Activities\n" +
+			"University of Texas,1992,1996,,Bachelor of Science,Chess Club\n",
+		"Skills.csv":       "Name\nProgramming\nCommunication\n",
+		"Publications.csv": "Name,Published On,Description,Publisher,Url\nTPS Reports,\"Mar 15, 1999\",A memo,Initech Press,https://example.com/tps\n",
+		"Connections.csv":  "Notes:\n\"When exporting your connection data...\"\n\nFirst Name,Last Name\nBill,Lumbergh\n",
+	})
+	resume, report, err := data.FromLinkedInReader(archive, archive.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	basics := resume.Basics
+	if basics.Name != "Peter Gibbons" || basics.Label != "Software Developer" || basics.Summary != "Fixes the Y2K bug, mostly" {
+		t.Fatalf("Unexpected basics: %+v", basics)
+	}
+	if basics.Email != "peter.gibbons@initech.com" || basics.Phone != "555-555-5555" || basics.Website != "https://peter.example.com" {
+		t.Fatalf("Unexpected contact details: %+v", basics)
+	}
+	if basics.Location.City != "Austin" || basics.Location.Region != "Texas" || basics.Location.PostalCode != "55555" {
+		t.Fatalf("Unexpected location: %+v", basics.Location)
+	}
+	if len(basics.Profiles) != 1 || basics.Profiles[0].Username != "pgibbons" {
+		t.Fatalf("Unexpected profiles: %+v", basics.Profiles)
+	}
+	if resume.Version != data.SCHEMA_VERSION {
+		t.Fatalf("Unexpected version: %d", resume.Version)
+	}
+
+	if len(resume.Work) != 2 || resume.Work[0].StartDate != "1998-02" || resume.Work[0].EndDate != "" {
+		t.Fatalf("Unexpected work: %+v", resume.Work)
+	}
+	if resume.Work[0].Extra.Get("location") != "Austin, Texas" {
+		t.Fatalf("Unexpected work location: %v", resume.Work[0].Extra)
+	}
+	if len(resume.Education) != 1 || resume.Education[0].StudyType != "Bachelor of Science" || resume.Education[0].EndDate != "1996" {
+		t.Fatalf("Unexpected education: %+v", resume.Education)
+	}
+	if len(resume.Skills) != 2 || resume.Skills[1].Name != "Communication" {
+		t.Fatalf("Unexpected skills: %+v", resume.Skills)
+	}
+	if len(resume.Publications) != 1 || resume.Publications[0].ReleaseDate != "1999-03-15" || resume.Publications[0].Publisher != "Initech Press" {
+		t.Fatalf("Unexpected publications: %+v", resume.Publications)
+	}
+
+	expectedUnmapped := map[string]bool{
+		"Profile.csv: Maiden Name":        true,
+		"Profile.csv: Birth Date":         true,
+		"Profile.csv: Industry":           true,
+		"Profile.csv: Instant Messengers": true,
+		"Email Addresses.csv: Confirmed":  true,
+		"Email Addresses.csv: Updated On": true,
+		"PhoneNumbers.csv: Extension":     true,
+		"PhoneNumbers.csv: Type":          true,
+		"Education.csv: Notes":            true,
+		"Education.csv: Activities":       true,
+	}
+	unmapped := map[string]bool{}
+	for _, field := range report.Unmapped {
+		unmapped[field.String()] = true
+	}
+	if !reflect.DeepEqual(expectedUnmapped, unmapped) {
+		t.Fatalf("Unexpected unmapped columns: %v", report.Unmapped)
+	}
+	if !reflect.DeepEqual(report.Ignored, []string{"Connections.csv"}) {
+		t.Fatalf("Unexpected ignored files: %v", report.Ignored)
+	}
+	if len(report.Notes) != 1 || resume.Work[1].EndDate != "Sometime in 1997" {
+		t.Fatalf("Expected a note about the unrecognized date, found: %v", report.Notes)
+	}
+}
+
+func TestNormalizeDate(t *testing.T) {
+	expected := map[string]data.Date{
+		"":              "",
+		"2019":          "2019",
+		"2019-02":       "2019-02",
+		"Feb 2019":      "2019-02",
+		"February 2019": "2019-02",
+		"Mar 15, 2018":  "2018-03-15",
+		"15 March 2018": "2018-03-15",
+		"3/15/2018":     "2018-03-15",
+		"03/2018":       "2018-03",
+		"Present":       data.Present,
+	}
+	for text, date := range expected {
+		normalized, ok := data.NormalizeDate(text)
+		if !ok || normalized != date {
+			t.Fatalf("Expected \"%s\" to normalize to \"%s\", found \"%s\"", text, date, normalized)
+		}
+	}
+	if normalized, ok := data.NormalizeDate("Spring 2018"); ok || normalized != "Spring 2018" {
+		t.Fatalf("Expected an unrecognized date to be returned unchanged, found \"%s\"", normalized)
+	}
+}
@@ -0,0 +1,101 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Migration is a single upgrade step, which converts resume data from one schema version to the next.
+type Migration struct {
+	// From is the schema version that this step upgrades.  After the step is applied, the data is at version
+	// "From + 1".
+	From int
+	// Description is a short, human-readable explanation of the step (e.g. for display by a command-line tool).
+	Description string
+	// Apply performs the upgrade in memory, returning a list of human-readable notes describing anything that
+	// it changed.  Apply does not need to update the "Version" field, as "Migrate()" handles that.
+	Apply func(data *ResumeData) ([]string, error)
+}
+
+// MigrationReport describes the outcome of a call to "Migrate()".
+type MigrationReport struct {
+	FromVersion int
+	ToVersion   int
+	Changes     []string
+}
+
+// Migrated returns true if any upgrade steps were applied.
+func (report MigrationReport) Migrated() bool {
+	return report.FromVersion != report.ToVersion
+}
+
+// migrations is the registry of upgrade steps, keyed by the schema version that each step upgrades.
+var migrations = map[int]Migration{}
+
+func init() {
+	RegisterMigration(Migration{
+		From:        0,
+		Description: "Stamp a schema version onto data that predates version tracking",
+		Apply: func(data *ResumeData) ([]string, error) {
+			return []string{"Added missing schema version"}, nil
+		},
+	})
+}
+
+// RegisterMigration adds an upgrade step to the registry.  It panics if a step is already registered for the
+// same "From" version, or if the step would upgrade data beyond the current SCHEMA_VERSION, since either case is
+// a programming error.
+func RegisterMigration(migration Migration) {
+	if _, exists := migrations[migration.From]; exists {
+		panic(fmt.Sprintf("data: migration from schema version %d is already registered", migration.From))
+	}
+	if migration.From < 0 || migration.From >= SCHEMA_VERSION {
+		panic(fmt.Sprintf("data: migration from schema version %d is outside of the current schema range", migration.From))
+	}
+	migrations[migration.From] = migration
+}
+
+// Migrations returns all registered upgrade steps, ordered by the schema version that they upgrade.
+func Migrations() []Migration {
+	list := make([]Migration, 0, len(migrations))
+	for _, migration := range migrations {
+		list = append(list, migration)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].From < list[j].From
+	})
+	return list
+}
+
+// DetectVersion returns the schema version of resume data that was loaded from a file.  Data files written before
+// ResumeFodder started tracking versions, as well as files created by other JSON-Resume tools, have no "version"
+// field at all... and are reported as version 0.
+func DetectVersion(data ResumeData) int {
+	return data.Version
+}
+
+// Migrate upgrades resume data in place, by applying each registered step between its detected version and the
+// current SCHEMA_VERSION in order.  Data from a newer version of ResumeFodder cannot be downgraded, and is
+// reported as an error.
+func Migrate(data *ResumeData) (MigrationReport, error) {
+	version := DetectVersion(*data)
+	report := MigrationReport{FromVersion: version, ToVersion: version}
+	if version > SCHEMA_VERSION {
+		return report, fmt.Errorf("Resume data has schema version %d, but this version of ResumeFodder only supports up to version %d", version, SCHEMA_VERSION)
+	}
+	for version < SCHEMA_VERSION {
+		migration, ok := migrations[version]
+		if !ok {
+			return report, fmt.Errorf("No migration is available from schema version %d", version)
+		}
+		changes, err := migration.Apply(data)
+		if err != nil {
+			return report, fmt.Errorf("Migration from schema version %d failed: %s", version, err)
+		}
+		version++
+		data.Version = version
+		report.ToVersion = version
+		report.Changes = append(report.Changes, changes...)
+	}
+	return report, nil
+}
@@ -0,0 +1,161 @@
+package data_test
+
+import (
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"reflect"
+	"testing"
+)
+
+func TestMigrateUnversioned(t *testing.T) {
+	// A file created by another JSON-Resume tool, with no "version" field
+	resume, err := data.FromJsonString(`{"basics": {"name": "Peter Gibbons"}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version := data.DetectVersion(resume); version != 0 {
+		t.Fatalf("Expected unversioned data to be detected as version 0, found %d", version)
+	}
+
+	report, err := data.Migrate(&resume)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Migrated() || report.FromVersion != 0 || report.ToVersion != data.SCHEMA_VERSION {
+		t.Fatalf("Unexpected migration report: %+v", report)
+	}
+	if len(report.Changes) == 0 {
+		t.Fatal("Expected the migration report to describe its changes")
+	}
+	if resume.Version != data.SCHEMA_VERSION {
+		t.Fatalf("Expected version %d after migration, found %d", data.SCHEMA_VERSION, resume.Version)
+	}
+	if resume.Basics.Name != "Peter Gibbons" {
+		t.Fatal("Migration lost existing resume data")
+	}
+}
+
+// legacyXml is a resume data file as written by ResumeFodder before it tracked schema versions:  there is no
+// "version" element, and the main and additional work history use the original fixed pair of fields.
+const legacyXml = `<resume>
+  <basics>
+    <name>Peter Gibbons</name>
+    <email>peter.gibbons@initech.com</email>
+    <summary>Just a straight-shooter with upper management written all over him</summary>
+    <location>
+      <city>Austin</city>
+      <region>TX</region>
+    </location>
+  </basics>
+  <work>
+    <company>Initech</company>
+    <position>Software Developer</position>
+    <startDate>1998-02-01</startDate>
+    <highlights>Updated bank software for the 2000 switch</highlights>
+    <highlights>Filed TPS reports with the new cover sheet</highlights>
+  </work>
+  <additionalWork>
+    <company>Flingers</company>
+    <position>Waiter</position>
+    <startDate>1995-05-01</startDate>
+    <endDate>1998-01-31</endDate>
+  </additionalWork>
+  <workLabel>Recent Experience</workLabel>
+  <additionalWorkLabel>Prior Experience</additionalWorkLabel>
+  <skills>
+    <name>Programming</name>
+    <keywords>Go</keywords>
+    <keywords>Java</keywords>
+  </skills>
+  <publications>
+    <name>Money Laundering for Dummies</name>
+    <releaseDate>1999-01-01</releaseDate>
+  </publications>
+</resume>`
+
+func TestMigrateLegacyFixture(t *testing.T) {
+	resume, err := data.FromXmlString(legacyXml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := data.Migrate(&resume)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.FromVersion != 0 || report.ToVersion != data.SCHEMA_VERSION {
+		t.Fatalf("Unexpected migration report: %+v", report)
+	}
+
+	// Every field of the legacy file is carried over unchanged, with only the version added
+	expected := data.ResumeData{
+		Version: data.SCHEMA_VERSION,
+		Basics: data.Basics{
+			Name:     "Peter Gibbons",
+			Email:    "peter.gibbons@initech.com",
+			Summary:  "Just a straight-shooter with upper management written all over him",
+			Location: data.Location{City: "Austin", Region: "TX"},
+		},
+		Work: []data.Work{{
+			Company:   "Initech",
+			Position:  "Software Developer",
+			StartDate: "1998-02-01",
+			Highlights: []data.Highlight{
+				{Text: "Updated bank software for the 2000 switch"},
+				{Text: "Filed TPS reports with the new cover sheet"},
+			},
+		}},
+		AdditionalWork:      []data.Work{{Company: "Flingers", Position: "Waiter", StartDate: "1995-05-01", EndDate: "1998-01-31"}},
+		WorkLabel:           "Recent Experience",
+		AdditionalWorkLabel: "Prior Experience",
+		Skills:              []data.Skill{{Name: "Programming", Keywords: []string{"Go", "Java"}}},
+		Publications:        []data.Publication{{Name: "Money Laundering for Dummies", ReleaseDate: "1999-01-01"}},
+	}
+	if !reflect.DeepEqual(expected, resume) {
+		t.Fatalf("Unexpected resume data after migration:\nExpected %+v\nFound    %+v", expected, resume)
+	}
+
+	// The migrated data converts to another format and back without loss
+	json, err := data.ToJsonString(resume)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJson, err := data.FromJsonString(json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resume, fromJson) {
+		t.Fatalf("Migrated resume data doesn't match after JSON conversion:\n%s", json)
+	}
+	if groups := fromJson.AllWorkGroups(); len(groups) != 2 || groups[1].Name != "Prior Experience" {
+		t.Fatalf("Expected the legacy work fields to be presented as two groups: %+v", groups)
+	}
+}
+
+func TestMigrateCurrent(t *testing.T) {
+	resume := data.NewResumeData()
+	report, err := data.Migrate(&resume)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Migrated() || len(report.Changes) > 0 {
+		t.Fatalf("Expected no changes for data already at the current version: %+v", report)
+	}
+}
+
+func TestMigrateNewerVersion(t *testing.T) {
+	resume := data.ResumeData{Version: data.SCHEMA_VERSION + 1}
+	if _, err := data.Migrate(&resume); err == nil {
+		t.Fatal("Expected an error when migrating data from a newer schema version")
+	}
+}
+
+func TestMigrationsOrdered(t *testing.T) {
+	migrations := data.Migrations()
+	if len(migrations) != data.SCHEMA_VERSION {
+		t.Fatalf("Expected %d registered migrations, found %d", data.SCHEMA_VERSION, len(migrations))
+	}
+	for index, migration := range migrations {
+		if migration.From != index {
+			t.Fatalf("Expected migration %d to upgrade from version %d, found %d", index, index, migration.From)
+		}
+	}
+}
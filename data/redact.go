@@ -0,0 +1,270 @@
+package data
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RedactionRule removes or masks the value at a field path, using the JSON field names joined by "." (e.g.
+// "basics.location.address").  A "[]" suffix applies the rest of the path to every entry of a list (e.g.
+// "work[].company"), and the last name may also be an extension field beginning with "x-" (e.g.
+// "basics.x-nickname").  If the mask is blank then the value is removed, and otherwise non-blank text values are
+// replaced with the mask... along with any mention of them elsewhere (e.g. an employer's name in a summary or URL).
+type RedactionRule struct {
+	Path string
+	Mask string
+}
+
+// Redaction is a set of rules for keeping private information out of an export (e.g. on a public web site), built
+// from the named profiles in "redactionProfiles" and/or individual field paths.  See "ParseRedaction()".
+type Redaction struct {
+	Rules []RedactionRule
+}
+
+// redactionProfiles are the named sets of rules available to "ParseRedaction()".
+//
+// "public" is meant for resumes published to the world, and drops the phone number, street address and postal code
+// along with references.  "anonymous" is meant for resumes circulated before an introduction (e.g. by a recruiter),
+// and also masks the names and web sites of employers, and of the other organizations (awarders, project clients
+// and volunteer organizations) that could identify them.
+var redactionProfiles = map[string][]RedactionRule{
+	"public": publicRedactionRules,
+	"anonymous": append(append([]RedactionRule{}, publicRedactionRules...),
+		RedactionRule{Path: "work[].company", Mask: "Confidential"},
+		RedactionRule{Path: "work[].website"},
+		RedactionRule{Path: "additionalWork[].company", Mask: "Confidential"},
+		RedactionRule{Path: "additionalWork[].website"},
+		RedactionRule{Path: "workGroups[].work[].company", Mask: "Confidential"},
+		RedactionRule{Path: "workGroups[].work[].website"},
+		RedactionRule{Path: "volunteer[].organization", Mask: "Confidential"},
+		RedactionRule{Path: "volunteer[].url"},
+		RedactionRule{Path: "awards[].awarder", Mask: "Confidential"},
+		RedactionRule{Path: "projects[].entity", Mask: "Confidential"},
+		RedactionRule{Path: "projects[].url"},
+	),
+}
+
+var publicRedactionRules = []RedactionRule{
+	{Path: "basics.phone"},
+	{Path: "basics.location.address"},
+	{Path: "basics.location.postalCode"},
+	{Path: "references"},
+}
+
+// RedactionProfiles returns the names of the built-in redaction profiles, sorted alphabetically.
+func RedactionProfiles() []string {
+	var names []string
+	for name := range redactionProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseRedaction builds a Redaction from a comma-separated list of profile names and field paths, with an optional
+// mask following a field path after "=" (e.g. "public,basics.email,work[].company=A Fortune 500 company").  Masks
+// therefore cannot contain commas.  An error is returned for any item that is neither a profile nor a field path
+// of "ResumeData".
+func ParseRedaction(expression string) (Redaction, error) {
+	redaction := Redaction{}
+	for _, item := range strings.Split(expression, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if rules, ok := redactionProfiles[item]; ok {
+			redaction.Rules = append(redaction.Rules, rules...)
+			continue
+		}
+		rule := RedactionRule{Path: item}
+		if index := strings.Index(item, "="); index >= 0 {
+			rule = RedactionRule{Path: strings.TrimSpace(item[:index]), Mask: strings.TrimSpace(item[index+1:])}
+		}
+		if !isRedactionPath(resumeDataType, strings.Split(rule.Path, ".")) {
+			return Redaction{}, fmt.Errorf("Unknown redaction profile or field path \"%s\".  The profiles are: %s.",
+				rule.Path, strings.Join(RedactionProfiles(), ", "))
+		}
+		redaction.Rules = append(redaction.Rules, rule)
+	}
+	return redaction, nil
+}
+
+// IsEmpty returns true if the redaction has no rules, and so would leave resume data unchanged.
+func (redaction Redaction) IsEmpty() bool {
+	return len(redaction.Rules) == 0
+}
+
+// Redact returns a copy of resume data with the redaction's rules applied.  The original data is left unmodified.
+func Redact(data ResumeData, redaction Redaction) ResumeData {
+	value := reflect.ValueOf(&data).Elem()
+	// masked maps each masked text value (in lower case) to its mask, so that mentions of it can be masked too
+	masked := map[string]string{}
+	for _, rule := range redaction.Rules {
+		redactValue(value, strings.Split(rule.Path, "."), rule.Mask, masked)
+	}
+	if len(masked) == 0 {
+		return data
+	}
+	return maskMentions(value, mentionPattern(masked), masked).Interface().(ResumeData)
+}
+
+func isRedactionPath(valueType reflect.Type, segments []string) bool {
+	name := strings.TrimSuffix(segments[0], "[]")
+	field, ok := structField(valueType, "json", name)
+	if !ok {
+		// Extension fields can't be checked, but can only be the last part of a path.  As in strict mode, their
+		// names must begin with "x-"... so that a misspelled profile name isn't taken for one.
+		_, hasExtra := valueType.FieldByName("Extra")
+		return hasExtra && len(segments) == 1 && name == segments[0] && strings.HasPrefix(name, "x-")
+	}
+	fieldType := field.Type
+	if strings.HasSuffix(segments[0], "[]") {
+		if fieldType.Kind() != reflect.Slice {
+			return false
+		}
+		fieldType = fieldType.Elem()
+	}
+	if len(segments) == 1 {
+		return true
+	}
+	return fieldType.Kind() == reflect.Struct && isRedactionPath(fieldType, segments[1:])
+}
+
+// redactValue applies a rule to an addressable struct value.  Lists and extension fields along the path are copied
+// before being changed, since they would otherwise be shared with the original data.
+func redactValue(value reflect.Value, segments []string, mask string, masked map[string]string) {
+	name := strings.TrimSuffix(segments[0], "[]")
+	each := strings.HasSuffix(segments[0], "[]")
+	field, ok := structField(value.Type(), "json", name)
+	if !ok {
+		extraValue := value.FieldByName("Extra")
+		if !extraValue.IsValid() || len(segments) > 1 {
+			return
+		}
+		extra := extraValue.Interface().(Extra)
+		if _, present := extra[name]; !present {
+			return
+		}
+		redacted := Extra{}
+		for key, item := range extra {
+			redacted[key] = item
+		}
+		if mask == "" {
+			delete(redacted, name)
+		} else {
+			if text, ok := extra[name].(string); ok && text != "" {
+				masked[strings.ToLower(text)] = mask
+			}
+			redacted[name] = mask
+		}
+		extraValue.Set(reflect.ValueOf(redacted))
+		return
+	}
+
+	fieldValue := value.FieldByIndex(field.Index)
+	switch {
+	case len(segments) == 1 && (!each || mask == ""):
+		redactField(fieldValue, mask, masked)
+	case each && fieldValue.Kind() == reflect.Slice:
+		copied := reflect.MakeSlice(fieldValue.Type(), fieldValue.Len(), fieldValue.Len())
+		reflect.Copy(copied, fieldValue)
+		fieldValue.Set(copied)
+		for index := 0; index < copied.Len(); index++ {
+			if len(segments) == 1 {
+				redactField(copied.Index(index), mask, masked)
+			} else if copied.Index(index).Kind() == reflect.Struct {
+				redactValue(copied.Index(index), segments[1:], mask, masked)
+			}
+		}
+	case fieldValue.Kind() == reflect.Struct:
+		redactValue(fieldValue, segments[1:], mask, masked)
+	}
+}
+
+func redactField(value reflect.Value, mask string, masked map[string]string) {
+	if mask != "" && value.Kind() == reflect.String {
+		if value.String() != "" {
+			masked[strings.ToLower(value.String())] = mask
+			value.SetString(mask)
+		}
+		return
+	}
+	value.Set(reflect.Zero(value.Type()))
+}
+
+// mentionPattern builds a case-insensitive expression matching any of the masked values, trying longer values first
+// so that a value containing another one is masked as a whole.  Values are only matched as whole words, so that a
+// short name such as "Go" doesn't mask part of "Gordon" or "algorithm".
+func mentionPattern(masked map[string]string) *regexp.Regexp {
+	var alternatives []string
+	for text := range masked {
+		alternative := regexp.QuoteMeta(text)
+		// "\b" only applies where the value begins or ends with a word character, e.g. not after the "+" in "C++"
+		if isWordByte(text[0]) {
+			alternative = `\b` + alternative
+		}
+		if isWordByte(text[len(text)-1]) {
+			alternative += `\b`
+		}
+		alternatives = append(alternatives, alternative)
+	}
+	sort.Slice(alternatives, func(i, j int) bool {
+		if len(alternatives[i]) != len(alternatives[j]) {
+			return len(alternatives[i]) > len(alternatives[j])
+		}
+		return alternatives[i] < alternatives[j]
+	})
+	return regexp.MustCompile("(?i)" + strings.Join(alternatives, "|"))
+}
+
+// isWordByte returns true if a byte is a word character, as matched by "\w" in "regexp".
+func isWordByte(b byte) bool {
+	return b == '_' || '0' <= b && b <= '9' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+// maskMentions returns a deep copy of a value, with every mention of a masked value in its text replaced by the mask.
+// Everything is copied rather than changed in place, since lists and extension fields are shared with the original
+// data.
+func maskMentions(value reflect.Value, pattern *regexp.Regexp, masked map[string]string) reflect.Value {
+	copied := reflect.New(value.Type()).Elem()
+	switch value.Kind() {
+	case reflect.String:
+		copied.SetString(pattern.ReplaceAllStringFunc(value.String(), func(mention string) string {
+			return masked[strings.ToLower(mention)]
+		}))
+	case reflect.Struct:
+		copied.Set(value)
+		for index := 0; index < value.NumField(); index++ {
+			if copied.Field(index).CanSet() {
+				copied.Field(index).Set(maskMentions(value.Field(index), pattern, masked))
+			}
+		}
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		copied.Set(reflect.MakeSlice(value.Type(), value.Len(), value.Len()))
+		for index := 0; index < value.Len(); index++ {
+			copied.Index(index).Set(maskMentions(value.Index(index), pattern, masked))
+		}
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		copied.Set(reflect.MakeMapWithSize(value.Type(), value.Len()))
+		for _, key := range value.MapKeys() {
+			copied.SetMapIndex(key, maskMentions(value.MapIndex(key), pattern, masked))
+		}
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		copied.Set(maskMentions(value.Elem(), pattern, masked))
+	default:
+		return value
+	}
+	return copied
+}
@@ -0,0 +1,93 @@
+package data_test
+
+import (
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRedact_Public(t *testing.T) {
+	redaction, err := data.ParseRedaction("public")
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := testutils.GenerateTestResumeData()
+	redacted := data.Redact(original, redaction)
+	if redacted.Basics.Phone != "" || redacted.Basics.Location.Address != "" || redacted.Basics.Location.PostalCode != "" {
+		t.Fatalf("Expected private contact details to be removed: %+v", redacted.Basics)
+	}
+	if len(redacted.References) > 0 {
+		t.Fatalf("Expected references to be removed: %+v", redacted.References)
+	}
+	if redacted.Basics.Location.City != original.Basics.Location.City || redacted.Work[0].Company != "Initech" {
+		t.Fatalf("Unexpected redaction: %+v", redacted)
+	}
+	if !reflect.DeepEqual(original, testutils.GenerateTestResumeData()) {
+		t.Fatal("The original data should be left unmodified")
+	}
+}
+
+func TestRedact_Anonymous(t *testing.T) {
+	redaction, err := data.ParseRedaction("anonymous, basics.x-nickname, basics.email=hidden@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := testutils.GenerateTestResumeData()
+	redacted := data.Redact(original, redaction)
+	if redacted.Basics.Phone != "" || len(redacted.References) > 0 {
+		t.Fatalf("Expected the public profile's rules to apply too: %+v", redacted)
+	}
+	for _, group := range redacted.AllWorkGroups() {
+		for _, work := range group.Work {
+			if work.Company != "Confidential" || work.Website != "" {
+				t.Fatalf("Expected employers to be masked: %+v", work)
+			}
+		}
+	}
+	if _, ok := redacted.Basics.Extra["x-nickname"]; ok || redacted.Basics.Email != "hidden@example.com" {
+		t.Fatalf("Expected the field path rules to apply: %+v", redacted.Basics)
+	}
+	if redacted.Awards[0].Awarder != "Confidential" || redacted.Projects[0].Entity != "Confidential" || redacted.Projects[0].Url != "" {
+		t.Fatalf("Expected other organizations naming the employer to be masked: %+v, %+v", redacted.Awards, redacted.Projects)
+	}
+	// Mentions of a masked value in other text are masked too, regardless of case
+	if summary := redacted.WorkGroups[0].Work[0].Summary; strings.Contains(summary, "Initech") || !strings.Contains(summary, "Confidential") {
+		t.Fatalf("Expected the employer's name to be masked in summaries: %s", summary)
+	}
+	if original.Work[0].Company != "Initech" || original.Basics.Extra["x-nickname"] != "Pete" ||
+		!strings.Contains(original.WorkGroups[0].Work[0].Summary, "Initech") {
+		t.Fatal("The original data should be left unmodified")
+	}
+}
+
+func TestRedact_ShortNames(t *testing.T) {
+	redaction, err := data.ParseRedaction("anonymous")
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := data.ResumeData{
+		Basics: data.Basics{Name: "Gordon", Summary: "Good with Go and Apple, and any algorithm.  Likes pineapple."},
+		Work:   []data.Work{{Company: "Go"}, {Company: "Apple"}},
+	}
+	redacted := data.Redact(original, redaction)
+	// Only whole words are masked, so that short names don't mask parts of other words
+	if redacted.Basics.Summary != "Good with Confidential and Confidential, and any algorithm.  Likes pineapple." {
+		t.Fatalf("Unexpected summary: %s", redacted.Basics.Summary)
+	}
+	if redacted.Basics.Name != "Gordon" {
+		t.Fatalf("Unexpected name: %s", redacted.Basics.Name)
+	}
+}
+
+func TestParseRedaction_UnknownPath(t *testing.T) {
+	for _, expression := range []string{"private", "basics.fax", "work.company", "basics.x-nickname.first"} {
+		if _, err := data.ParseRedaction(expression); err == nil {
+			t.Fatalf("Expected an error for \"%s\"", expression)
+		}
+	}
+	if redaction, err := data.ParseRedaction(""); err != nil || !redaction.IsEmpty() {
+		t.Fatalf("Expected an empty redaction, found %+v, %v", redaction, err)
+	}
+}
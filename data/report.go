@@ -0,0 +1,86 @@
+package data
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// UnmappedField is a piece of data that could not be carried across when importing resume data from another format
+// (or exporting to one), because there is no matching field on the other side.
+type UnmappedField struct {
+	// Source is where the data came from, e.g. a file within an archive ("Positions.csv") or a section of resume
+	// data ("basics").
+	Source string
+	// Field is the name of the column, element or property that was left behind.
+	Field string
+}
+
+func (field UnmappedField) String() string {
+	return fmt.Sprintf("%s: %s", field.Source, field.Field)
+}
+
+// MappingReport describes the data that was left behind by an import or export.
+type MappingReport struct {
+	// Unmapped lists each field that could not be mapped, once per source no matter how many entries used it.
+	Unmapped []UnmappedField
+	// Ignored lists whole sources (e.g. files within an archive) that were not read at all.
+	Ignored []string
+	// Notes lists values that were carried across, but may need checking (e.g. dates in an unrecognized format).
+	Notes []string
+}
+
+// addUnmapped records an unmapped field, unless it was already recorded for the same source.
+func (report *MappingReport) addUnmapped(source, field string) {
+	for _, existing := range report.Unmapped {
+		if existing.Source == source && existing.Field == field {
+			return
+		}
+	}
+	report.Unmapped = append(report.Unmapped, UnmappedField{Source: source, Field: field})
+}
+
+// ReportingCodec is implemented by codecs for foreign formats (e.g. Europass), which can't represent every field of
+// the other side.  Its methods behave like "Decode()" and "Encode()", but also report the data that was left behind.
+type ReportingCodec interface {
+	Codec
+	DecodeWithReport(reader io.Reader) (ResumeData, MappingReport, error)
+	EncodeWithReport(data ResumeData, writer io.Writer) (MappingReport, error)
+}
+
+// joinBullets combines a summary and a list of bullet points into a single text field, for foreign formats that
+// have no list of highlights (e.g. Europass "Activities").
+func joinBullets(summary string, bullets []string) string {
+	lines := []string{}
+	if summary != "" {
+		lines = append(lines, summary)
+	}
+	for _, bullet := range bullets {
+		lines = append(lines, "- "+bullet)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// splitBullets splits a text field written by "joinBullets()" back into a summary and a list of bullet points.
+func splitBullets(text string) (string, []string) {
+	var summary []string
+	var bullets []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "• ") {
+			bullets = append(bullets, strings.TrimSpace(strings.TrimLeft(line, "-• ")))
+		} else if line != "" {
+			summary = append(summary, line)
+		}
+	}
+	return strings.Join(summary, "\n"), bullets
+}
+
+// highlightTexts returns the text of each highlight.
+func highlightTexts(highlights []Highlight) []string {
+	var texts []string
+	for _, highlight := range highlights {
+		texts = append(texts, highlight.Text)
+	}
+	return texts
+}
@@ -0,0 +1,73 @@
+package data
+
+import "sort"
+
+// Role is a single position held at an employer.  A "Work" entry with several roles represents a career
+// progression (e.g. promotions) at the same company.
+type Role struct {
+	Title      string      `xml:"title" json:"title"`
+	StartDate  Date        `xml:"startDate" json:"startDate"`
+	EndDate    Date        `xml:"endDate" json:"endDate"`
+	Summary    string      `xml:"summary" json:"summary"`
+	Highlights []Highlight `xml:"highlights" json:"highlights"`
+	Extra      Extra       `xml:",any" json:"-"`
+}
+
+// AllRoles returns the roles held at this employer, most recent first.  A work entry without any "Roles" is
+// presented as a single role, built from its own "Position", dates, "Summary" and "Highlights"... so that templates
+// can render every entry the same way.
+func (work Work) AllRoles() []Role {
+	if len(work.Roles) == 0 {
+		return []Role{{
+			Title:      work.Position,
+			StartDate:  work.StartDate,
+			EndDate:    work.EndDate,
+			Summary:    work.Summary,
+			Highlights: work.Highlights,
+		}}
+	}
+	roles := make([]Role, len(work.Roles))
+	copy(roles, work.Roles)
+	sort.SliceStable(roles, func(i, j int) bool {
+		comparison, ok := roles[i].StartDate.Compare(roles[j].StartDate)
+		return ok && comparison > 0
+	})
+	return roles
+}
+
+// Tenure returns the overall date range of employment, spanning the entry's own dates and those of every role.  The
+// end date is "present" if any role ends at "present", and blank (i.e. ongoing) if the most recent role has no
+// end date.
+func (work Work) Tenure() (start Date, end Date) {
+	start, end = work.StartDate, work.EndDate
+	if len(work.Roles) == 0 {
+		return start, end
+	}
+	for _, role := range work.Roles {
+		if comparison, ok := role.StartDate.Compare(start); start.IsBlank() || (ok && comparison < 0) {
+			start = role.StartDate
+		}
+	}
+	latest := work.AllRoles()[0]
+	if latest.EndDate.IsBlank() && work.EndDate.IsBlank() {
+		return start, ""
+	}
+	for _, role := range work.Roles {
+		if comparison, ok := role.EndDate.Compare(end); end.IsBlank() || (ok && comparison > 0) {
+			end = role.EndDate
+		}
+	}
+	return start, end
+}
+
+// TenureStart returns the start of the overall date range from "Tenure()", for use in templates.
+func (work Work) TenureStart() Date {
+	start, _ := work.Tenure()
+	return start
+}
+
+// TenureEnd returns the end of the overall date range from "Tenure()", for use in templates.
+func (work Work) TenureEnd() Date {
+	_, end := work.Tenure()
+	return end
+}
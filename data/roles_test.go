@@ -0,0 +1,80 @@
+package data_test
+
+import (
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"testing"
+)
+
+func TestAllRoles(t *testing.T) {
+	work := testutils.GenerateTestResumeData().Work[0]
+	roles := work.AllRoles()
+	if len(roles) != 2 || roles[0].Title != "Senior Software Developer" || roles[1].Title != "Software Developer" {
+		t.Fatalf("Expected roles with the most recent first, found: %+v", roles)
+	}
+
+	// An entry without roles is presented as a single role
+	work = testutils.GenerateTestResumeData().AdditionalWork[0]
+	roles = work.AllRoles()
+	if len(roles) != 1 || roles[0].Title != work.Position || roles[0].EndDate != work.EndDate || len(roles[0].Highlights) != 2 {
+		t.Fatalf("Expected a single role built from the work entry, found: %+v", roles)
+	}
+}
+
+func TestTenure(t *testing.T) {
+	tests := []struct {
+		work       data.Work
+		start, end data.Date
+	}{
+		{
+			data.Work{StartDate: "2001", EndDate: "2003"},
+			"2001", "2003",
+		},
+		{
+			data.Work{Roles: []data.Role{
+				{StartDate: "2005-01", EndDate: "2007-06"},
+				{StartDate: "2001-03", EndDate: "2004-12"},
+			}},
+			"2001-03", "2007-06",
+		},
+		{
+			data.Work{Roles: []data.Role{
+				{StartDate: "2001", EndDate: "2004"},
+				{StartDate: "2004", EndDate: data.Present},
+			}},
+			"2001", data.Present,
+		},
+		{
+			data.Work{Roles: []data.Role{
+				{StartDate: "2001", EndDate: "2004"},
+				{StartDate: "2004-06"},
+			}},
+			"2001", "",
+		},
+	}
+	for index, test := range tests {
+		start, end := test.work.Tenure()
+		if start != test.start || end != test.end {
+			t.Fatalf("Test %d: expected tenure \"%s\" to \"%s\", found \"%s\" to \"%s\"", index, test.start, test.end, start, end)
+		}
+	}
+}
+
+func TestRolesRoundTrip(t *testing.T) {
+	resume, err := data.FromJsonString(`{"work": [{"company": "Initech", "roles": [{"title": "Developer", "startDate": "1998", "highlights": ["Y2K"]}]}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xml, err := data.ToXmlString(resume)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromXml, err := data.FromXmlString(xml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roles := fromXml.Work[0].Roles
+	if len(roles) != 1 || roles[0].Title != "Developer" || roles[0].StartDate != "1998" || roles[0].Highlights[0].Text != "Y2K" {
+		t.Fatalf("Roles were not preserved through conversion: %+v", roles)
+	}
+}
@@ -0,0 +1,135 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// jsonSchema is the subset of JSON Schema (draft 7) used by the schemas bundled with ResumeFodder (in the "schema"
+// directory):  "type", "properties", "required", "items", "additionalProperties" (as a boolean), "pattern",
+// "minLength", and "$ref" to an entry in the root schema's "definitions".  Other keywords are ignored... including
+// "format", so that e.g. a "uri" or "email" property is only checked for being a string.
+type jsonSchema struct {
+	Type                 string                 `json:"type"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	Required             []string               `json:"required"`
+	Items                *jsonSchema            `json:"items"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Pattern              string                 `json:"pattern"`
+	MinLength            int                    `json:"minLength"`
+	Ref                  string                 `json:"$ref"`
+	Definitions          map[string]*jsonSchema `json:"definitions"`
+}
+
+// SchemaError is returned when a document doesn't match one of the bundled schemas.  Each issue names the path of
+// the offending property (e.g. "candidate.profiles[0].employment[1].start").
+type SchemaError struct {
+	Schema string
+	Issues []string
+}
+
+func (err SchemaError) Error() string {
+	return fmt.Sprintf("The document does not match the %s schema:\n  %s", err.Schema, strings.Join(err.Issues, "\n  "))
+}
+
+// mustLoadSchema parses a bundled schema.  It panics if the schema is malformed, since that is a programming error.
+func mustLoadSchema(schemaBytes []byte) *jsonSchema {
+	schema := &jsonSchema{}
+	if err := json.Unmarshal(schemaBytes, schema); err != nil {
+		panic(fmt.Sprintf("Malformed bundled schema: %s", err))
+	}
+	return schema
+}
+
+// validate checks a generic JSON value (as decoded by "encoding/json") against the schema, returning a
+// "SchemaError" listing every issue found.
+func (schema *jsonSchema) validate(name string, value interface{}) error {
+	var issues []string
+	schema.check(schema, value, "", &issues)
+	if len(issues) > 0 {
+		return SchemaError{Schema: name, Issues: issues}
+	}
+	return nil
+}
+
+func (schema *jsonSchema) check(root *jsonSchema, value interface{}, path string, issues *[]string) {
+	if schema.Ref != "" {
+		definition, ok := root.Definitions[strings.TrimPrefix(schema.Ref, "#/definitions/")]
+		if !ok {
+			panic(fmt.Sprintf("Unresolved reference \"%s\" in bundled schema", schema.Ref))
+		}
+		definition.check(root, value, path, issues)
+		return
+	}
+	label := path
+	if label == "" {
+		label = "(document)"
+	}
+	fail := func(format string, args ...interface{}) {
+		*issues = append(*issues, label+": "+fmt.Sprintf(format, args...))
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		if schema.Type != "" && schema.Type != "object" {
+			fail("expected %s, found an object", schema.Type)
+			return
+		}
+		for _, required := range schema.Required {
+			if _, ok := typed[required]; !ok {
+				fail("missing required property \"%s\"", required)
+			}
+		}
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if property, ok := schema.Properties[key]; ok {
+				property.check(root, typed[key], childPath, issues)
+			} else if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+				fail("unexpected property \"%s\"", key)
+			}
+		}
+	case []interface{}:
+		if schema.Type != "" && schema.Type != "array" {
+			fail("expected %s, found an array", schema.Type)
+			return
+		}
+		if schema.Items != nil {
+			for index, item := range typed {
+				schema.Items.check(root, item, fmt.Sprintf("%s[%d]", path, index), issues)
+			}
+		}
+	case string:
+		if schema.Type != "" && schema.Type != "string" {
+			fail("expected %s, found a string", schema.Type)
+			return
+		}
+		if len(typed) < schema.MinLength {
+			fail("must not be blank")
+		} else if schema.Pattern != "" && !regexp.MustCompile(schema.Pattern).MatchString(typed) {
+			fail("\"%s\" does not match the pattern \"%s\"", typed, schema.Pattern)
+		}
+	case bool:
+		if schema.Type != "" && schema.Type != "boolean" {
+			fail("expected %s, found a boolean", schema.Type)
+		}
+	case float64, json.Number:
+		if schema.Type != "" && schema.Type != "number" && schema.Type != "integer" {
+			fail("expected %s, found a number", schema.Type)
+		}
+	case nil:
+		if schema.Type != "" && schema.Type != "null" {
+			fail("expected %s, found null", schema.Type)
+		}
+	}
+}
@@ -0,0 +1,516 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Diagnostic is a single problem found while strictly decoding a resume data file.
+type Diagnostic struct {
+	// File is the name of the file being decoded, or blank when decoding a string.
+	File   string
+	Line   int
+	Column int
+	// Path is a JSON-pointer-style location of the offending field, using the field names from the file (e.g.
+	// "/work/0/hightlights").
+	Path    string
+	Message string
+}
+
+func (diagnostic Diagnostic) String() string {
+	location := fmt.Sprintf("%d:%d", diagnostic.Line, diagnostic.Column)
+	if diagnostic.File != "" {
+		location = diagnostic.File + ":" + location
+	}
+	if diagnostic.Path == "" {
+		return location + ": " + diagnostic.Message
+	}
+	return location + ": " + diagnostic.Path + ": " + diagnostic.Message
+}
+
+// DecodeError is returned by the strict decoding functions, and lists every problem found in the file.
+type DecodeError struct {
+	Diagnostics []Diagnostic
+}
+
+func (err DecodeError) Error() string {
+	lines := make([]string, 0, len(err.Diagnostics))
+	for _, diagnostic := range err.Diagnostics {
+		lines = append(lines, diagnostic.String())
+	}
+	return "Resume data could not be decoded:\n" + strings.Join(lines, "\n")
+}
+
+// FromXmlStringStrict is a variant of "FromXmlString()" that rejects unrecognized elements and values of the wrong
+// type, rather than ignoring them or collecting them as extra fields.  Any problems are reported in a "DecodeError".
+//
+// Strict mode still accepts the extension properties that are legitimately found in resume data files from other
+// tools:  any property name beginning with "x-", plus the top-level JSON-Resume "$schema" and "meta" properties.
+func FromXmlStringStrict(xmlString string) (ResumeData, error) {
+	return fromXmlStrict([]byte(xmlString), "")
+}
+
+// FromXmlFileStrict is a variant of "FromXmlFile()" that rejects unrecognized elements and values of the wrong type.
+// See "FromXmlStringStrict()".
+func FromXmlFileStrict(xmlFilename string) (ResumeData, error) {
+	bytes, err := ioutil.ReadFile(xmlFilename)
+	if err != nil {
+		return ResumeData{}, err
+	}
+	return fromXmlStrict(bytes, xmlFilename)
+}
+
+// FromXmlReaderStrict is a variant of "FromXmlReader()" that rejects unrecognized elements and values of the wrong
+// type.  See "FromXmlStringStrict()".  The whole stream is read before decoding, so that diagnostics can report line
+// and column numbers.
+func FromXmlReaderStrict(reader io.Reader) (ResumeData, error) {
+	bytes, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return ResumeData{}, err
+	}
+	return fromXmlStrict(bytes, "")
+}
+
+// FromJsonStringStrict is a variant of "FromJsonString()" that rejects unrecognized properties and values of the
+// wrong type.  See "FromXmlStringStrict()".
+func FromJsonStringStrict(jsonString string) (ResumeData, error) {
+	return fromJsonStrict([]byte(jsonString), "")
+}
+
+// FromJsonFileStrict is a variant of "FromJsonFile()" that rejects unrecognized properties and values of the wrong
+// type.  See "FromXmlStringStrict()".
+func FromJsonFileStrict(jsonFilename string) (ResumeData, error) {
+	bytes, err := ioutil.ReadFile(jsonFilename)
+	if err != nil {
+		return ResumeData{}, err
+	}
+	return fromJsonStrict(bytes, jsonFilename)
+}
+
+// FromJsonReaderStrict is a variant of "FromJsonReader()" that rejects unrecognized properties and values of the
+// wrong type.  See "FromXmlReaderStrict()".
+func FromJsonReaderStrict(reader io.Reader) (ResumeData, error) {
+	bytes, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return ResumeData{}, err
+	}
+	return fromJsonStrict(bytes, "")
+}
+
+func fromXmlStrict(xmlBytes []byte, filename string) (ResumeData, error) {
+	checker := xmlChecker{filename: filename, source: xmlBytes, decoder: xml.NewDecoder(bytes.NewReader(xmlBytes))}
+	checker.checkDocument()
+	if len(checker.diagnostics) > 0 {
+		return ResumeData{}, DecodeError{Diagnostics: checker.diagnostics}
+	}
+	return FromXmlReader(bytes.NewReader(xmlBytes))
+}
+
+func fromJsonStrict(jsonBytes []byte, filename string) (ResumeData, error) {
+	checker := jsonChecker{filename: filename, source: jsonBytes, decoder: json.NewDecoder(bytes.NewReader(jsonBytes)),
+		overlay: jsonHasExtends(jsonBytes)}
+	checker.checkDocument()
+	if len(checker.diagnostics) > 0 {
+		return ResumeData{}, DecodeError{Diagnostics: checker.diagnostics}
+	}
+	return FromJsonReader(bytes.NewReader(jsonBytes))
+}
+
+var (
+	resumeDataType = reflect.TypeOf(ResumeData{})
+	highlightType  = reflect.TypeOf(Highlight{})
+)
+
+// keyedEntryTypes holds the types of the entries in "keyedLists", wherever those lists appear in ResumeData.
+var keyedEntryTypes = findKeyedEntryTypes(resumeDataType, map[reflect.Type]bool{})
+
+func findKeyedEntryTypes(structType reflect.Type, found map[reflect.Type]bool) map[reflect.Type]bool {
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Slice {
+			fieldType = fieldType.Elem()
+			if _, ok := keyedLists[strings.Split(field.Tag.Get("json"), ",")[0]]; ok {
+				found[fieldType] = true
+			}
+		}
+		if fieldType.Kind() == reflect.Struct && fieldType != structType {
+			findKeyedEntryTypes(fieldType, found)
+		}
+	}
+	return found
+}
+
+// isAllowedExtension returns true for unrecognized property names that strict mode nevertheless accepts.  The
+// "$delete" directive is only accepted in the entries of keyed lists, and only in an overlay (i.e. a document with
+// an "extends" property), since it has no meaning anywhere else.
+func isAllowedExtension(parentType reflect.Type, name string, overlay bool) bool {
+	if strings.HasPrefix(name, "x-") {
+		return true
+	}
+	if name == DeleteDirective {
+		return overlay && keyedEntryTypes[parentType]
+	}
+	return parentType == resumeDataType && (name == "$schema" || name == "meta" || name == ExtendsProperty)
+}
+
+// jsonHasExtends returns true if a JSON document has a top-level "extends" property, and so is an overlay (see
+// "ResolveFile()").
+func jsonHasExtends(jsonBytes []byte) bool {
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(jsonBytes, &document); err != nil {
+		return false
+	}
+	_, ok := document[ExtendsProperty]
+	return ok
+}
+
+// structField finds the struct field for a property name, by the given tag ("json" or "xml").  As with
+// "encoding/json", JSON property names are matched case-insensitively.
+func structField(structType reflect.Type, tagName, name string) (reflect.StructField, bool) {
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		options := strings.Split(field.Tag.Get(tagName), ",")
+		tag := options[0]
+		if field.PkgPath != "" || tag == "-" || tag == "" || field.Name == "XMLName" {
+			continue
+		}
+		// Attributes (e.g. a highlight's tags) can't be written as child elements
+		if tagName == "xml" && len(options) > 1 && options[1] == "attr" {
+			continue
+		}
+		if tag == name || (tagName == "json" && strings.EqualFold(tag, name)) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func describeType(valueType reflect.Type) string {
+	switch valueType.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Int:
+		return "an integer"
+	case reflect.Slice:
+		return "an array"
+	case reflect.Struct:
+		if valueType == highlightType {
+			return "a string or an object"
+		}
+		return "an object"
+	}
+	return valueType.String()
+}
+
+// jsonChecker walks through a JSON document token by token, comparing it against the structure of "ResumeData".
+type jsonChecker struct {
+	filename    string
+	source      []byte
+	decoder     *json.Decoder
+	diagnostics []Diagnostic
+	// offset is the position in the source where the most recently read token begins.
+	offset int
+	// overlay is true if the document extends a base file, and so may contain "$delete" directives.
+	overlay bool
+}
+
+func (checker *jsonChecker) checkDocument() {
+	if err := checker.checkValue(resumeDataType, ""); err != nil {
+		checker.syntaxError(err)
+	}
+}
+
+func (checker *jsonChecker) next() (json.Token, error) {
+	// The decoder's offset is at the end of the previous token, so skip ahead past whitespace and separators to
+	// find where the next token begins.
+	offset := int(checker.decoder.InputOffset())
+	for offset < len(checker.source) && strings.IndexByte(" \t\r\n,:", checker.source[offset]) >= 0 {
+		offset++
+	}
+	checker.offset = offset
+	return checker.decoder.Token()
+}
+
+func (checker *jsonChecker) add(offset int, path, message string) {
+	line, column := sourcePosition(checker.source, offset)
+	checker.diagnostics = append(checker.diagnostics, Diagnostic{
+		File: checker.filename, Line: line, Column: column, Path: path, Message: message,
+	})
+}
+
+// sourcePosition returns the line and column of an offset in a source file, both counted from 1.  Columns are counted
+// in characters rather than bytes, so that they match what an editor shows for lines with non-ASCII text.
+func sourcePosition(source []byte, offset int) (line, column int) {
+	line, column = 1, 1
+	for _, char := range string(source[:offset]) {
+		if char == '\n' {
+			line, column = line+1, 1
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
+
+func (checker *jsonChecker) syntaxError(err error) {
+	offset := checker.offset
+	if syntaxErr, ok := err.(*json.SyntaxError); ok {
+		offset = int(syntaxErr.Offset)
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if offset > len(checker.source) {
+		offset = len(checker.source)
+	}
+	checker.add(offset, "", err.Error())
+}
+
+// checkValue reads one complete value, and reports a diagnostic for anything that doesn't fit the expected type.
+// Only syntax errors (after which the rest of the document can't be read) are returned as errors.
+func (checker *jsonChecker) checkValue(valueType reflect.Type, path string) error {
+	token, err := checker.next()
+	if err != nil {
+		return err
+	}
+	start := checker.offset
+	if token == nil {
+		// As with "encoding/json", null is accepted for any type and leaves the field empty
+		return nil
+	}
+	mismatch := func(found string) error {
+		checker.add(start, path, fmt.Sprintf("expected %s, found %s", describeType(valueType), found))
+		return checker.skip(token)
+	}
+
+	switch valueType.Kind() {
+	case reflect.String:
+		if _, ok := token.(string); !ok {
+			return mismatch(describeJsonToken(token))
+		}
+	case reflect.Int:
+		number, ok := token.(float64)
+		if !ok {
+			return mismatch(describeJsonToken(token))
+		}
+		if number != float64(int(number)) {
+			checker.add(start, path, fmt.Sprintf("expected an integer, found %s", strconv.FormatFloat(number, 'g', -1, 64)))
+		}
+	case reflect.Slice:
+		if token != json.Delim('[') {
+			return mismatch(describeJsonToken(token))
+		}
+		for index := 0; checker.decoder.More(); index++ {
+			if err := checker.checkValue(valueType.Elem(), fmt.Sprintf("%s/%d", path, index)); err != nil {
+				return err
+			}
+		}
+		_, err := checker.next()
+		return err
+	case reflect.Struct:
+		if valueType == highlightType {
+			if _, ok := token.(string); ok {
+				return nil
+			}
+		}
+		if token != json.Delim('{') {
+			return mismatch(describeJsonToken(token))
+		}
+		for checker.decoder.More() {
+			keyToken, err := checker.next()
+			if err != nil {
+				return err
+			}
+			key := keyToken.(string)
+			keyOffset := checker.offset
+			keyPath := path + "/" + strings.Replace(strings.Replace(key, "~", "~0", -1), "/", "~1", -1)
+			if field, ok := structField(valueType, "json", key); ok {
+				if err := checker.checkValue(field.Type, keyPath); err != nil {
+					return err
+				}
+				continue
+			}
+			if !isAllowedExtension(valueType, key, checker.overlay) {
+				checker.add(keyOffset, keyPath, fmt.Sprintf("unknown field \"%s\"", key))
+			}
+			if err := checker.skipValue(); err != nil {
+				return err
+			}
+		}
+		_, err := checker.next()
+		return err
+	}
+	return nil
+}
+
+// skip consumes the rest of a value whose first token has already been read.
+func (checker *jsonChecker) skip(token json.Token) error {
+	if token != json.Delim('[') && token != json.Delim('{') {
+		return nil
+	}
+	for depth := 1; depth > 0; {
+		token, err := checker.next()
+		if err != nil {
+			return err
+		}
+		if token == json.Delim('[') || token == json.Delim('{') {
+			depth++
+		} else if token == json.Delim(']') || token == json.Delim('}') {
+			depth--
+		}
+	}
+	return nil
+}
+
+func (checker *jsonChecker) skipValue() error {
+	token, err := checker.next()
+	if err != nil {
+		return err
+	}
+	return checker.skip(token)
+}
+
+func describeJsonToken(token json.Token) string {
+	switch typed := token.(type) {
+	case json.Delim:
+		if typed == '[' {
+			return "an array"
+		}
+		return "an object"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	}
+	return "null"
+}
+
+// xmlChecker walks through an XML document token by token, comparing it against the structure of "ResumeData".
+type xmlChecker struct {
+	filename    string
+	decoder     *xml.Decoder
+	source      []byte
+	diagnostics []Diagnostic
+	// offset is the position in the source where the most recently read token begins.
+	offset int
+}
+
+func (checker *xmlChecker) next() (xml.Token, error) {
+	checker.offset = int(checker.decoder.InputOffset())
+	return checker.decoder.Token()
+}
+
+func (checker *xmlChecker) add(offset int, path, message string) {
+	line, column := sourcePosition(checker.source, offset)
+	checker.diagnostics = append(checker.diagnostics, Diagnostic{
+		File: checker.filename, Line: line, Column: column, Path: path, Message: message,
+	})
+}
+
+func (checker *xmlChecker) syntaxError(err error) {
+	offset := checker.offset
+	if _, ok := err.(*xml.SyntaxError); ok {
+		// The decoder stops reading where it found the error
+		offset = int(checker.decoder.InputOffset())
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if offset > len(checker.source) {
+		offset = len(checker.source)
+	}
+	checker.add(offset, "", err.Error())
+}
+
+func (checker *xmlChecker) checkDocument() {
+	for {
+		token, err := checker.next()
+		if err != nil {
+			checker.syntaxError(err)
+			return
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			if start.Name.Local != "resume" {
+				checker.add(checker.offset, "", fmt.Sprintf("expected a <resume> root element, found <%s>", start.Name.Local))
+			}
+			if err := checker.checkElement(resumeDataType, start, ""); err != nil {
+				checker.syntaxError(err)
+			}
+			return
+		}
+	}
+}
+
+// checkElement reads the contents of an element whose start tag has already been read, and reports a diagnostic for
+// anything that doesn't fit the expected type.  Only syntax errors are returned as errors.
+func (checker *xmlChecker) checkElement(valueType reflect.Type, start xml.StartElement, path string) error {
+	offset := checker.offset
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" || (valueType == highlightType && attr.Name.Local == "tags") {
+			continue
+		}
+		checker.add(offset, path+"/@"+attr.Name.Local, fmt.Sprintf("unknown attribute \"%s\"", attr.Name.Local))
+	}
+
+	var text bytes.Buffer
+	// counts holds the number of elements seen so far for each list field, to give the index of the next one
+	counts := map[string]int{}
+	for {
+		token, err := checker.next()
+		if err != nil {
+			return err
+		}
+		switch typed := token.(type) {
+		case xml.CharData:
+			text.Write(typed)
+		case xml.StartElement:
+			childPath := path + "/" + typed.Name.Local
+			if valueType.Kind() != reflect.Struct {
+				checker.add(checker.offset, childPath, fmt.Sprintf("expected %s, found element <%s>", describeType(valueType), typed.Name.Local))
+				if err := checker.decoder.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			field, ok := structField(valueType, "xml", typed.Name.Local)
+			if !ok {
+				// "$delete" isn't a legal element name, so there's no need to know whether this is an overlay
+				if !isAllowedExtension(valueType, typed.Name.Local, false) {
+					checker.add(checker.offset, childPath, fmt.Sprintf("unknown element <%s>", typed.Name.Local))
+				}
+				if err := checker.decoder.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			// Slices are written as repeated elements, so each element holds a single item... and its path includes
+			// the item's index, as in the JSON encoding
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Slice {
+				fieldType = fieldType.Elem()
+				childPath = fmt.Sprintf("%s/%d", childPath, counts[typed.Name.Local])
+				counts[typed.Name.Local]++
+			}
+			if err := checker.checkElement(fieldType, typed, childPath); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			if valueType.Kind() == reflect.Int {
+				if _, err := strconv.Atoi(strings.TrimSpace(text.String())); err != nil {
+					checker.add(offset, path, fmt.Sprintf("expected an integer, found \"%s\"", strings.TrimSpace(text.String())))
+				}
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package data_test
+
+import (
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// checkDiagnostics verifies that an error is a "DecodeError" with the expected diagnostics, compared by line,
+// column and path.
+func checkDiagnostics(t *testing.T, err error, expected []data.Diagnostic) {
+	decodeErr, ok := err.(data.DecodeError)
+	if !ok {
+		t.Fatalf("Expected a DecodeError, found: %v", err)
+	}
+	if len(decodeErr.Diagnostics) != len(expected) {
+		t.Fatalf("Expected %d diagnostics, found:\n%v", len(expected), decodeErr)
+	}
+	for index, diagnostic := range decodeErr.Diagnostics {
+		e := expected[index]
+		if diagnostic.File != e.File || diagnostic.Line != e.Line || diagnostic.Column != e.Column || diagnostic.Path != e.Path {
+			t.Fatalf("Expected diagnostic %s:%d:%d %s, found: %s", e.File, e.Line, e.Column, e.Path, diagnostic)
+		}
+	}
+}
+
+func TestStrictJson(t *testing.T) {
+	json := `{
+  "version": 1.5,
+  "basics": {"name": 42, "x-pronouns": "he/him"},
+  "work": [
+    {
+      "company": "Initech",
+      "hightlights": ["Y2K"],
+      "highlights": ["Y2K", {"text": "TPS", "tags": "backend"}]
+    }
+  ],
+  "skills": {"name": "C++"},
+  "meta": {"version": "v1.0.0"}
+}`
+	_, err := data.FromJsonStringStrict(json)
+	checkDiagnostics(t, err, []data.Diagnostic{
+		{Line: 2, Column: 14, Path: "/version"},
+		{Line: 3, Column: 22, Path: "/basics/name"},
+		{Line: 7, Column: 7, Path: "/work/0/hightlights"},
+		{Line: 8, Column: 53, Path: "/work/0/highlights/1/tags"},
+		{Line: 11, Column: 13, Path: "/skills"},
+	})
+}
+
+func TestStrictDeleteDirective(t *testing.T) {
+	// "$delete" is only accepted in the entries of keyed lists, and only in an overlay
+	_, err := data.FromJsonStringStrict(`{"work": [{"company": "Initech", "$delete": true}]}`)
+	checkDiagnostics(t, err, []data.Diagnostic{{Line: 1, Column: 34, Path: "/work/0/$delete"}})
+
+	overlay := `{"extends": "base.json", "basics": {"name": "Peter", "$delete": true}, ` +
+		`"work": [{"company": "Initech", "$delete": true}]}`
+	_, err = data.FromJsonStringStrict(overlay)
+	checkDiagnostics(t, err, []data.Diagnostic{{Line: 1, Column: 54, Path: "/basics/$delete"}})
+}
+
+func TestStrictJsonSyntaxError(t *testing.T) {
+	_, err := data.FromJsonStringStrict("{\n  \"basics\": {\"name\": \"Peter\",}\n}")
+	checkDiagnostics(t, err, []data.Diagnostic{{Line: 2, Column: 30}})
+}
+
+func TestStrictXmlSyntaxError(t *testing.T) {
+	_, err := data.FromXmlStringStrict("<resume>\n  <basics><name>Peter</nam></basics>\n</resume>")
+	checkDiagnostics(t, err, []data.Diagnostic{{Line: 2, Column: 28}})
+}
+
+func TestStrictNonAsciiColumns(t *testing.T) {
+	// Columns are counted in characters, so the multi-byte "ü" and "日本" count as one each
+	_, err := data.FromJsonStringStrict(`{"basics": {"name": "Jürgen 日本", "nickname": "J"}}`)
+	checkDiagnostics(t, err, []data.Diagnostic{{Line: 1, Column: 34, Path: "/basics/nickname"}})
+
+	_, err = data.FromXmlStringStrict("<resume><basics><name>Jürgen 日本</name><nickname>J</nickname></basics></resume>")
+	checkDiagnostics(t, err, []data.Diagnostic{{Line: 1, Column: 39, Path: "/basics/nickname"}})
+}
+
+func TestStrictXml(t *testing.T) {
+	xml := `<resume>
+  <version>one</version>
+  <basics>
+    <name>Peter Gibbons</name>
+    <nickname>Pete</nickname>
+  </basics>
+  <work>
+    <company><name>Initech</name></company>
+    <highlights>Y2K</highlights>
+    <highlights tags="backend" priority="high">TPS</highlights>
+  </work>
+</resume>`
+	_, err := data.FromXmlStringStrict(xml)
+	checkDiagnostics(t, err, []data.Diagnostic{
+		{Line: 2, Column: 3, Path: "/version"},
+		{Line: 5, Column: 5, Path: "/basics/nickname"},
+		{Line: 8, Column: 14, Path: "/work/0/company/name"},
+		{Line: 10, Column: 5, Path: "/work/0/highlights/1/@priority"},
+	})
+}
+
+func TestStrictPathsMatch(t *testing.T) {
+	// The same mistake is reported at the same path in either encoding
+	json := `{"work": [{"company": "Initech"}, {"company": "Initrode", "highlights": ["Y2K", {"text": "TPS", "x": 1}]}]}`
+	_, err := data.FromJsonStringStrict(json)
+	checkDiagnostics(t, err, []data.Diagnostic{{Line: 1, Column: 97, Path: "/work/1/highlights/1/x"}})
+
+	xml := `<resume>
+  <work><company>Initech</company></work>
+  <work>
+    <company>Initrode</company>
+    <highlights>Y2K</highlights>
+    <highlights>TPS<x>1</x></highlights>
+  </work>
+</resume>`
+	_, err = data.FromXmlStringStrict(xml)
+	checkDiagnostics(t, err, []data.Diagnostic{{Line: 6, Column: 20, Path: "/work/1/highlights/1/x"}})
+}
+
+func TestStrictFiles(t *testing.T) {
+	jsonFilename := filepath.Join(os.TempDir(), "testresume.json")
+	testutils.DeleteFileIfExists(t, jsonFilename)
+	defer testutils.DeleteFileIfExists(t, jsonFilename)
+	xmlFilename := filepath.Join(os.TempDir(), "testresume.xml")
+	testutils.DeleteFileIfExists(t, xmlFilename)
+	defer testutils.DeleteFileIfExists(t, xmlFilename)
+
+	// Files written by ResumeFodder itself always pass strict decoding
+	originalData := testutils.GenerateTestResumeData()
+	originalData.Basics.Extra = nil
+	if err := data.ToJsonFile(originalData, jsonFilename); err != nil {
+		t.Fatal(err)
+	}
+	fromJsonData, err := data.FromJsonFileStrict(jsonFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(originalData, fromJsonData) {
+		t.Fatal("Resume data after strict JSON decoding doesn't match the original")
+	}
+	if err := data.ToXmlFile(originalData, xmlFilename); err != nil {
+		t.Fatal(err)
+	}
+	fromXmlData, err := data.FromXmlFileStrict(xmlFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(originalData, fromXmlData) {
+		t.Fatal("Resume data after strict XML decoding doesn't match the original")
+	}
+
+	// Diagnostics include the filename
+	originalData.Basics.Extra = data.Extra{"nickname": "Pete"}
+	if err := data.ToJsonFile(originalData, jsonFilename); err != nil {
+		t.Fatal(err)
+	}
+	_, err = data.FromJsonFileStrict(jsonFilename)
+	if decodeErr, ok := err.(data.DecodeError); !ok || decodeErr.Diagnostics[0].File != jsonFilename {
+		t.Fatalf("Expected a diagnostic for %s, found: %v", jsonFilename, err)
+	}
+}
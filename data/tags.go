@@ -0,0 +1,256 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+)
+
+// Tags is a list of free-form labels (e.g. "backend", "management"), used to select which entries appear in a
+// particular variant of a resume.  See "Selector" and "Filter()".
+//
+// Tags is an extra field, not found within the standard JSON-Resume spec.  Other JSON-Resume processors will
+// ignore it, and include every entry.
+type Tags []string
+
+// MarshalXMLAttr writes tags as a single space-separated attribute, for use on elements whose content is plain text
+// (i.e. "Highlight").  An empty list omits the attribute altogether.
+func (tags Tags) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if len(tags) == 0 {
+		return xml.Attr{}, nil
+	}
+	return xml.Attr{Name: name, Value: strings.Join(tags, " ")}, nil
+}
+
+// UnmarshalXMLAttr reads tags from a single space-separated attribute.
+func (tags *Tags) UnmarshalXMLAttr(attr xml.Attr) error {
+	*tags = strings.Fields(attr.Value)
+	return nil
+}
+
+// Has returns true if the list contains the given tag.  Comparison is case-insensitive.
+func (tags Tags) Has(tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Highlight is a single bullet point within a work history entry.  In JSON, a highlight without tags or extra
+// properties is written as a plain string (exactly as in the JSON-Resume spec), and any other highlight is written as
+// an object with "text" and "tags" fields alongside the extra properties.  In XML, any tags are written as a
+// space-separated "tags" attribute, and any extra properties as child elements following the text.
+type Highlight struct {
+	Text  string `xml:",chardata" json:"text"`
+	Tags  Tags   `xml:"tags,attr" json:"tags"`
+	Extra Extra  `xml:",any" json:"-"`
+}
+
+// String returns the highlight text, so that templates can render a highlight with "{{.}}" just as they would a
+// plain string.
+func (highlight Highlight) String() string {
+	return highlight.Text
+}
+
+func (highlight Highlight) MarshalJSON() ([]byte, error) {
+	if len(highlight.Tags) == 0 && len(highlight.Extra) == 0 {
+		return json.Marshal(highlight.Text)
+	}
+	// Marshal through a type alias, so that this method isn't called recursively
+	type highlightObject Highlight
+	return marshalWithExtra(highlightObject(highlight), highlight.Extra)
+}
+
+func (highlight *Highlight) UnmarshalJSON(jsonBytes []byte) error {
+	var text string
+	if err := json.Unmarshal(jsonBytes, &text); err == nil {
+		*highlight = Highlight{Text: text}
+		return nil
+	}
+	type highlightObject Highlight
+	var object highlightObject
+	if err := unmarshalWithExtra(jsonBytes, &object, &object.Extra); err != nil {
+		return err
+	}
+	*highlight = Highlight(object)
+	return nil
+}
+
+// UnmarshalXML reads a highlight's text and tags, along with any extra properties written as child elements.  When
+// there are child elements, the whitespace around the text is only indentation, and so is trimmed.
+func (highlight *Highlight) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	*highlight = Highlight{}
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "tags" {
+			if err := highlight.Tags.UnmarshalXMLAttr(attr); err != nil {
+				return err
+			}
+		}
+	}
+	var text bytes.Buffer
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch typed := token.(type) {
+		case xml.CharData:
+			text.Write(typed)
+		case xml.StartElement:
+			if err := highlight.Extra.UnmarshalXML(decoder, typed); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			highlight.Text = text.String()
+			if highlight.Extra != nil {
+				highlight.Text = strings.TrimSpace(highlight.Text)
+			}
+			return nil
+		}
+	}
+}
+
+// Selector chooses which tagged entries to keep, when building a variant of a resume (e.g. a "backend" resume
+// versus a "management" resume) from a single data file.
+//
+// Each expression in Include and Exclude is a tag, or several tags joined by "+" to require all of them (e.g.
+// "data+senior").  Entries without any tags are common to every variant, and are always kept.  A tagged entry is
+// kept if it matches at least one Include expression (or if there are no Include expressions), and matches no
+// Exclude expressions.
+type Selector struct {
+	Include []string
+	Exclude []string
+}
+
+// ParseSelector builds a Selector from a comma-separated list of tag expressions, with excluded expressions
+// prefixed by "!" or "-" (e.g. "backend,data+senior,!management").
+func ParseSelector(expression string) Selector {
+	selector := Selector{}
+	for _, term := range strings.Split(expression, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		if strings.HasPrefix(term, "!") || strings.HasPrefix(term, "-") {
+			selector.Exclude = append(selector.Exclude, strings.TrimSpace(term[1:]))
+		} else {
+			selector.Include = append(selector.Include, term)
+		}
+	}
+	return selector
+}
+
+// IsEmpty returns true if the selector has no expressions, and so would keep every entry.
+func (selector Selector) IsEmpty() bool {
+	return len(selector.Include) == 0 && len(selector.Exclude) == 0
+}
+
+// Matches returns true if an entry with the given tags should be kept.
+func (selector Selector) Matches(tags Tags) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	for _, expression := range selector.Exclude {
+		if tagExpressionMatches(expression, tags) {
+			return false
+		}
+	}
+	if len(selector.Include) == 0 {
+		return true
+	}
+	for _, expression := range selector.Include {
+		if tagExpressionMatches(expression, tags) {
+			return true
+		}
+	}
+	return false
+}
+
+func tagExpressionMatches(expression string, tags Tags) bool {
+	for _, tag := range strings.Split(expression, "+") {
+		if !tags.Has(strings.TrimSpace(tag)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns a copy of resume data containing only the work history, education, publications, skills and work
+// highlights that are matched by the selector.  The original data is left unmodified.
+func Filter(data ResumeData, selector Selector) ResumeData {
+	if selector.IsEmpty() {
+		return data
+	}
+	data.Work = filterWork(data.Work, selector)
+	data.AdditionalWork = filterWork(data.AdditionalWork, selector)
+	var workGroups []WorkGroup
+	for _, group := range data.WorkGroups {
+		group.Work = filterWork(group.Work, selector)
+		workGroups = append(workGroups, group)
+	}
+	data.WorkGroups = workGroups
+	var education []Education
+	for _, entry := range data.Education {
+		if selector.Matches(entry.Tags) {
+			education = append(education, entry)
+		}
+	}
+	data.Education = education
+	data.Publications = filterPublications(data.Publications, selector)
+	data.AdditionalPublications = filterPublications(data.AdditionalPublications, selector)
+	var publicationGroups []PublicationGroup
+	for _, group := range data.PublicationGroups {
+		group.Publications = filterPublications(group.Publications, selector)
+		publicationGroups = append(publicationGroups, group)
+	}
+	data.PublicationGroups = publicationGroups
+	var skills []Skill
+	for _, entry := range data.Skills {
+		if selector.Matches(entry.Tags) {
+			skills = append(skills, entry)
+		}
+	}
+	data.Skills = skills
+	return data
+}
+
+func filterWork(list []Work, selector Selector) []Work {
+	var filtered []Work
+	for _, entry := range list {
+		if !selector.Matches(entry.Tags) {
+			continue
+		}
+		entry.Highlights = filterHighlights(entry.Highlights, selector)
+		var roles []Role
+		for _, role := range entry.Roles {
+			role.Highlights = filterHighlights(role.Highlights, selector)
+			roles = append(roles, role)
+		}
+		entry.Roles = roles
+		filtered = append(filtered, entry)
+	}
+	return filtered
+}
+
+func filterHighlights(list []Highlight, selector Selector) []Highlight {
+	var filtered []Highlight
+	for _, highlight := range list {
+		if selector.Matches(highlight.Tags) {
+			filtered = append(filtered, highlight)
+		}
+	}
+	return filtered
+}
+
+func filterPublications(list []Publication, selector Selector) []Publication {
+	var filtered []Publication
+	for _, entry := range list {
+		if selector.Matches(entry.Tags) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,122 @@
+package data_test
+
+import (
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSelector(t *testing.T) {
+	selector := data.ParseSelector("backend, data+senior,!management,-sales")
+	expected := data.Selector{
+		Include: []string{"backend", "data+senior"},
+		Exclude: []string{"management", "sales"},
+	}
+	if !reflect.DeepEqual(expected, selector) {
+		t.Fatalf("Expected %+v, found %+v", expected, selector)
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	selector := data.ParseSelector("backend,data+senior,!management")
+	tests := []struct {
+		tags    data.Tags
+		matches bool
+	}{
+		{nil, true},
+		{data.Tags{"Backend"}, true},
+		{data.Tags{"data"}, false},
+		{data.Tags{"data", "senior"}, true},
+		{data.Tags{"backend", "management"}, false},
+		{data.Tags{"frontend"}, false},
+	}
+	for _, test := range tests {
+		if matches := selector.Matches(test.tags); matches != test.matches {
+			t.Fatalf("Expected %v to match %t, found %t", test.tags, test.matches, matches)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	original := testutils.GenerateTestResumeData()
+	filtered := data.Filter(original, data.ParseSelector("backend"))
+
+	if len(filtered.Work) != 1 || len(filtered.Work[0].Highlights) != 1 || !strings.Contains(filtered.Work[0].Highlights[0].Text, "Y2K") {
+		t.Fatalf("Expected only the backend work highlight to remain, found: %+v", filtered.Work)
+	}
+	if len(filtered.AdditionalWork) != 1 || len(filtered.AdditionalWork[0].Highlights) != 2 {
+		t.Fatalf("Expected untagged work to remain intact, found: %+v", filtered.AdditionalWork)
+	}
+	if len(filtered.Skills) != 1 || filtered.Skills[0].Name != "Programming" {
+		t.Fatalf("Expected only the backend skill to remain, found: %+v", filtered.Skills)
+	}
+	if !reflect.DeepEqual(original, testutils.GenerateTestResumeData()) {
+		t.Fatal("Filtering modified the original resume data")
+	}
+
+	filtered = data.Filter(original, data.ParseSelector("!backend"))
+	if len(filtered.Work) != 0 {
+		t.Fatalf("Expected work tagged \"backend\" to be excluded, found: %+v", filtered.Work)
+	}
+}
+
+func TestHighlightJson(t *testing.T) {
+	resume, err := data.FromJsonString(`{"work": [{"highlights": ["Plain", {"text": "Tagged", "tags": ["backend"]}]}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []data.Highlight{{Text: "Plain"}, {Text: "Tagged", Tags: data.Tags{"backend"}}}
+	if !reflect.DeepEqual(expected, resume.Work[0].Highlights) {
+		t.Fatalf("Expected %+v, found %+v", expected, resume.Work[0].Highlights)
+	}
+	json, err := data.ToJsonString(resume)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(json, `"Plain"`) {
+		t.Fatalf("Expected an untagged highlight to be written as a plain string:\n%s", json)
+	}
+}
+
+func TestHighlightExtra(t *testing.T) {
+	resume, err := data.FromJsonString(`{"work": [{"highlights": [{"text": "TPS reports", "x-priority": "high"}]}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []data.Highlight{{Text: "TPS reports", Extra: data.Extra{"x-priority": "high"}}}
+	if !reflect.DeepEqual(expected, resume.Work[0].Highlights) {
+		t.Fatalf("Expected %+v, found %+v", expected, resume.Work[0].Highlights)
+	}
+
+	json, err := data.ToJsonString(resume)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(json, `"x-priority": "high"`) {
+		t.Fatalf("Expected the extra property to be written back out:\n%s", json)
+	}
+	fromJson, err := data.FromJsonString(json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resume, fromJson) {
+		t.Fatalf("Highlight extra properties don't match after JSON conversion:\n%s", json)
+	}
+
+	xml, err := data.ToXmlString(resume)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromXml, err := data.FromXmlString(xml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resume, fromXml) {
+		t.Fatalf("Highlight extra properties don't match after XML conversion: %+v\n%s", fromXml.Work[0].Highlights, xml)
+	}
+	if _, err := data.FromXmlStringStrict(xml); err != nil {
+		t.Fatalf("Expected strict mode to accept an \"x-\" extension on a highlight: %s", err)
+	}
+}
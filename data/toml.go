@@ -0,0 +1,110 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/BurntSushi/toml"
+	"io"
+	"strings"
+	"time"
+)
+
+// FromTomlString loads a ResumeData struct from a string of TOML text.  TOML files use the same field names as JSON
+// files, with lists of entries (e.g. "work", "education" and "publications") written as arrays of tables:
+//
+//	[[work]]
+//	company = "Initech"
+//	startDate = 1998-02-01
+func FromTomlString(tomlString string) (ResumeData, error) {
+	return FromTomlReader(strings.NewReader(tomlString))
+}
+
+// FromTomlFile loads a ResumeData struct from a TOML file.
+func FromTomlFile(tomlFilename string) (ResumeData, error) {
+	return readFile(tomlFilename, FromTomlReader)
+}
+
+// FromTomlReader loads a ResumeData struct from a stream of TOML text.  This provides the core logic for
+// `FromTomlString` and `FromTomlFile`.  As with YAML, the TOML is parsed into generic values and then run through the
+// JSON decoding logic.
+func FromTomlReader(reader io.Reader) (ResumeData, error) {
+	var document map[string]interface{}
+	if _, err := toml.NewDecoder(reader).Decode(&document); err != nil {
+		return ResumeData{}, err
+	}
+	jsonBytes, err := json.Marshal(coerceGeneric(document, resumeDataType))
+	if err != nil {
+		return ResumeData{}, err
+	}
+	return FromJsonReader(bytes.NewReader(jsonBytes))
+}
+
+// ToTomlString writes a ResumeData struct to a string of TOML text.
+func ToTomlString(data ResumeData) (string, error) {
+	return writeString(data, ToTomlWriter)
+}
+
+// ToTomlFile writes a ResumeData struct to a TOML file.
+func ToTomlFile(data ResumeData, tomlFilename string) error {
+	return writeFile(data, tomlFilename, ToTomlWriter)
+}
+
+// ToTomlWriter writes a ResumeData struct to a stream of TOML text.  This provides the core logic for `ToTomlString`
+// and `ToTomlFile`.  TOML has no null value, so any null extra fields are left out.
+func ToTomlWriter(data ResumeData, writer io.Writer) error {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return err
+	}
+	return toml.NewEncoder(writer).Encode(tomlValue(document))
+}
+
+// tomlValue converts a generic JSON value into a form that the TOML encoder accepts, dropping null values and
+// converting numbers to integers where possible.
+func tomlValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			if item != nil {
+				converted[key] = tomlValue(item)
+			}
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, 0, len(typed))
+		for _, item := range typed {
+			if item != nil {
+				converted = append(converted, tomlValue(item))
+			}
+		}
+		return converted
+	case json.Number:
+		if integer, err := typed.Int64(); err == nil {
+			return integer
+		}
+		float, _ := typed.Float64()
+		return float
+	}
+	return value
+}
+
+// formatTomlTime converts a TOML date, time or date-time value back into text, using the same layout that it was
+// written in (e.g. an unquoted "startDate = 1998-02-01" becomes the string "1998-02-01").
+func formatTomlTime(value time.Time) string {
+	switch value.Location().String() {
+	case "date-local":
+		return value.Format("2006-01-02")
+	case "time-local":
+		return value.Format("15:04:05.999999999")
+	case "datetime-local":
+		return value.Format("2006-01-02T15:04:05.999999999")
+	}
+	return value.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,124 @@
+package data_test
+
+import (
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTomlConversion(t *testing.T) {
+	originalData := testutils.GenerateTestResumeData()
+
+	toml, err := data.ToTomlString(originalData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromTomlData, err := data.FromTomlString(toml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(originalData, fromTomlData) {
+		t.Fatal("Resume data after TOML conversion doesn't match the original")
+	}
+}
+
+func TestXmlToTomlConversion(t *testing.T) {
+	originalData := testutils.GenerateTestResumeData()
+
+	xml, err := data.ToXmlString(originalData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromXmlData, err := data.FromXmlString(xml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	toml, err := data.ToTomlString(fromXmlData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromTomlData, err := data.FromTomlString(toml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(originalData, fromTomlData) {
+		t.Fatal("Resume data after XML-to-TOML conversion doesn't match the original")
+	}
+}
+
+func TestTomlFileConversion(t *testing.T) {
+	tomlFilename := filepath.Join(os.TempDir(), "testresume.toml")
+	testutils.DeleteFileIfExists(t, tomlFilename)
+	defer testutils.DeleteFileIfExists(t, tomlFilename)
+
+	originalData := testutils.GenerateTestResumeData()
+	err := data.ToTomlFile(originalData, tomlFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromTomlData, err := data.FromTomlFile(tomlFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(originalData, fromTomlData) {
+		t.Fatal("Resume data after TOML conversion doesn't match the original")
+	}
+}
+
+func TestTomlArraysOfTables(t *testing.T) {
+	toml, err := data.ToTomlString(testutils.GenerateTestResumeData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range []string{"[[work]]", "[[work.roles]]", "[[education]]", "[[publications]]"} {
+		if !strings.Contains(toml, table) {
+			t.Fatalf("Expected an array of tables \"%s\":\n%s", table, toml)
+		}
+	}
+
+	resume, err := data.FromTomlString(`version = 1
+
+[basics]
+name = "Peter Gibbons"
+phone = 5555555555
+
+[[work]]
+company = "Initech"
+startDate = 1998-02-01
+endDate = 1999
+highlights = ["Fixed the Y2K bug", { text = "Led the TPS project", tags = ["management"] }]
+
+[[education]]
+institution = "University of Texas"
+endDate = 1997-05-15
+
+[[publications]]
+name = "TPS Reports Considered Harmful"
+releaseDate = 1999-06-01
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resume.Basics.Phone != "5555555555" {
+		t.Fatalf("Unexpected phone: %q", resume.Basics.Phone)
+	}
+	work := resume.Work[0]
+	if work.StartDate != "1998-02-01" || work.EndDate != "1999" {
+		t.Fatalf("Unexpected dates: %q to %q", work.StartDate, work.EndDate)
+	}
+	if len(work.Highlights) != 2 || !work.Highlights[1].Tags.Has("management") {
+		t.Fatalf("Unexpected highlights: %+v", work.Highlights)
+	}
+	if resume.Education[0].EndDate != "1997-05-15" {
+		t.Fatalf("Unexpected education end date: %q", resume.Education[0].EndDate)
+	}
+	if resume.Publications[0].ReleaseDate != "1999-06-01" {
+		t.Fatalf("Unexpected release date: %q", resume.Publications[0].ReleaseDate)
+	}
+}
@@ -0,0 +1,324 @@
+package data
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Severity indicates how serious a validation issue is.  Errors describe data that will render incorrectly (or not
+// at all), while warnings describe data that is probably a mistake but won't break a template.
+type Severity int
+
+const (
+	SeverityWarning Severity = iota
+	SeverityError
+)
+
+func (severity Severity) String() string {
+	if severity == SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// Rule identifiers, reported in the "Rule" field of each validation issue.
+const (
+	RuleRequired   = "required"
+	RuleDateFormat = "date-format"
+	RuleDateRange  = "date-range"
+	RuleEmail      = "email"
+	RuleUrl        = "url"
+	RuleEmptyEntry = "empty-entry"
+)
+
+// Issue is a single problem found by "Validate()".
+type Issue struct {
+	Severity Severity
+	// Path is a JSON-pointer-style location of the offending field, using the JSON field names (e.g.
+	// "/work/2/startDate").
+	Path    string
+	Message string
+	Rule    string
+}
+
+func (issue Issue) String() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", issue.Severity, issue.Path, issue.Message, issue.Rule)
+}
+
+// ValidationError is returned by operations that refuse to proceed with resume data containing validation errors.
+type ValidationError struct {
+	Issues []Issue
+}
+
+func (err ValidationError) Error() string {
+	lines := make([]string, 0, len(err.Issues))
+	for _, issue := range err.Issues {
+		lines = append(lines, issue.String())
+	}
+	return "Resume data failed validation:\n" + strings.Join(lines, "\n")
+}
+
+// HasErrors returns true if any of the issues have error severity.
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks resume data for problems that would cause a template to render poorly: missing required fields,
+// dates that aren't in a JSON-Resume format (see "Date"), date ranges that end before they start, malformed email
+// addresses and URLs, and empty placeholder entries (e.g. those left over from a file generated by "NewResumeData()").
+func Validate(data ResumeData) []Issue {
+	v := validator{}
+	v.required("/basics/name", data.Basics.Name)
+	v.email("/basics/email", data.Basics.Email)
+	v.url("/basics/website", data.Basics.Website)
+	v.url("/basics/picture", data.Basics.Picture)
+	v.strings("/basics/highlights", data.Basics.Highlights)
+	for index, profile := range data.Basics.Profiles {
+		path := fmt.Sprintf("/basics/profiles/%d", index)
+		if v.empty(path, profile) {
+			continue
+		}
+		v.required(path+"/network", profile.Network)
+		v.url(path+"/url", profile.Url)
+	}
+	v.work("/work", data.Work)
+	v.work("/additionalWork", data.AdditionalWork)
+	for index, group := range data.WorkGroups {
+		v.work(fmt.Sprintf("/workGroups/%d/work", index), group.Work)
+	}
+	for index, volunteer := range data.Volunteer {
+		path := fmt.Sprintf("/volunteer/%d", index)
+		if v.empty(path, volunteer) {
+			continue
+		}
+		v.required(path+"/organization", volunteer.Organization)
+		v.url(path+"/url", volunteer.Url)
+		v.dateRange(path, volunteer.StartDate, volunteer.EndDate)
+		v.strings(path+"/highlights", volunteer.Highlights)
+	}
+	for index, education := range data.Education {
+		path := fmt.Sprintf("/education/%d", index)
+		if v.empty(path, education) {
+			continue
+		}
+		v.required(path+"/institution", education.Institution)
+		v.dateRange(path, education.StartDate, education.EndDate)
+		v.strings(path+"/courses", education.Courses)
+	}
+	for index, award := range data.Awards {
+		path := fmt.Sprintf("/awards/%d", index)
+		if v.empty(path, award) {
+			continue
+		}
+		v.required(path+"/title", award.Title)
+		v.date(path+"/date", award.Date)
+	}
+	for index, certificate := range data.Certificates {
+		path := fmt.Sprintf("/certificates/%d", index)
+		if v.empty(path, certificate) {
+			continue
+		}
+		v.required(path+"/name", certificate.Name)
+		v.date(path+"/date", certificate.Date)
+		v.url(path+"/url", certificate.Url)
+	}
+	v.publications("/publications", data.Publications)
+	v.publications("/additionalPublications", data.AdditionalPublications)
+	for index, group := range data.PublicationGroups {
+		v.publications(fmt.Sprintf("/publicationGroups/%d/publications", index), group.Publications)
+	}
+	for index, skill := range data.Skills {
+		path := fmt.Sprintf("/skills/%d", index)
+		if v.empty(path, skill) {
+			continue
+		}
+		v.required(path+"/name", skill.Name)
+		v.strings(path+"/keywords", skill.Keywords)
+	}
+	for index, language := range data.Languages {
+		path := fmt.Sprintf("/languages/%d", index)
+		if v.empty(path, language) {
+			continue
+		}
+		v.required(path+"/language", language.Language)
+	}
+	for index, interest := range data.Interests {
+		path := fmt.Sprintf("/interests/%d", index)
+		if v.empty(path, interest) {
+			continue
+		}
+		v.required(path+"/name", interest.Name)
+		v.strings(path+"/keywords", interest.Keywords)
+	}
+	for index, reference := range data.References {
+		path := fmt.Sprintf("/references/%d", index)
+		if v.empty(path, reference) {
+			continue
+		}
+		v.required(path+"/name", reference.Name)
+	}
+	for index, project := range data.Projects {
+		path := fmt.Sprintf("/projects/%d", index)
+		if v.empty(path, project) {
+			continue
+		}
+		v.required(path+"/name", project.Name)
+		v.url(path+"/url", project.Url)
+		v.dateRange(path, project.StartDate, project.EndDate)
+		v.strings(path+"/highlights", project.Highlights)
+	}
+	return v.issues
+}
+
+// validator accumulates issues while "Validate()" walks through the resume data.
+type validator struct {
+	issues []Issue
+}
+
+func (v *validator) add(severity Severity, path, rule, message string) {
+	v.issues = append(v.issues, Issue{Severity: severity, Path: path, Message: message, Rule: rule})
+}
+
+func (v *validator) work(basePath string, list []Work) {
+	for index, work := range list {
+		path := fmt.Sprintf("%s/%d", basePath, index)
+		if v.empty(path, work) {
+			continue
+		}
+		v.required(path+"/company", work.Company)
+		if len(work.Roles) == 0 {
+			// With multiple roles, the title of each role takes the place of the position
+			v.required(path+"/position", work.Position)
+		}
+		v.url(path+"/website", work.Website)
+		v.dateRange(path, work.StartDate, work.EndDate)
+		v.highlights(path+"/highlights", work.Highlights)
+		for roleIndex, role := range work.Roles {
+			rolePath := fmt.Sprintf("%s/roles/%d", path, roleIndex)
+			if v.empty(rolePath, role) {
+				continue
+			}
+			v.required(rolePath+"/title", role.Title)
+			v.dateRange(rolePath, role.StartDate, role.EndDate)
+			v.highlights(rolePath+"/highlights", role.Highlights)
+		}
+	}
+}
+
+func (v *validator) publications(basePath string, list []Publication) {
+	for index, publication := range list {
+		path := fmt.Sprintf("%s/%d", basePath, index)
+		if v.empty(path, publication) {
+			continue
+		}
+		v.required(path+"/name", publication.Name)
+		v.url(path+"/website", publication.Website)
+		v.date(path+"/releaseDate", publication.ReleaseDate)
+	}
+}
+
+// empty reports an entry with no content at all, and returns true so that the caller can skip any further
+// checks on it.
+func (v *validator) empty(path string, entry interface{}) bool {
+	if !isEmptyValue(reflect.ValueOf(entry)) {
+		return false
+	}
+	v.add(SeverityWarning, path, RuleEmptyEntry, "Entry is empty, and should be filled in or removed")
+	return true
+}
+
+func (v *validator) required(path, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(SeverityError, path, RuleRequired, "Field is required")
+	}
+}
+
+func (v *validator) strings(basePath string, values []string) {
+	for index, value := range values {
+		if strings.TrimSpace(value) == "" {
+			v.add(SeverityWarning, fmt.Sprintf("%s/%d", basePath, index), RuleEmptyEntry, "Entry is empty, and should be filled in or removed")
+		}
+	}
+}
+
+func (v *validator) highlights(basePath string, highlights []Highlight) {
+	for index, highlight := range highlights {
+		if strings.TrimSpace(highlight.Text) == "" {
+			v.add(SeverityWarning, fmt.Sprintf("%s/%d", basePath, index), RuleEmptyEntry, "Entry is empty, and should be filled in or removed")
+		}
+	}
+}
+
+func (v *validator) email(path, value string) {
+	if value == "" {
+		return
+	}
+	if address, err := mail.ParseAddress(value); err != nil || address.Address != value {
+		v.add(SeverityWarning, path, RuleEmail, fmt.Sprintf("\"%s\" is not a valid email address", value))
+	}
+}
+
+func (v *validator) url(path, value string) {
+	if value == "" {
+		return
+	}
+	if parsed, err := url.Parse(value); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		v.add(SeverityWarning, path, RuleUrl, fmt.Sprintf("\"%s\" is not a valid absolute URL", value))
+	}
+}
+
+func (v *validator) date(path string, value Date) {
+	if !value.Valid() {
+		v.add(SeverityError, path, RuleDateFormat, fmt.Sprintf("\"%s\" is not in YYYY, YYYY-MM or YYYY-MM-DD format", value))
+	}
+}
+
+func (v *validator) dateRange(path string, startDate, endDate Date) {
+	v.date(path+"/startDate", startDate)
+	v.date(path+"/endDate", endDate)
+	if startDate.IsPresent() {
+		v.add(SeverityError, path+"/startDate", RuleDateRange, "Start date cannot be \"present\"")
+		return
+	}
+	// "Compare()" only checks to the precision that both dates share, so "2014" is not considered before "2014-06".
+	if comparison, ok := endDate.Compare(startDate); ok && comparison < 0 {
+		v.add(SeverityError, path+"/endDate", RuleDateRange, fmt.Sprintf("End date \"%s\" is before start date \"%s\"", endDate, startDate))
+	}
+}
+
+// isEmptyValue returns true if a value holds nothing but blank strings, empty slices, and nested structs of the
+// same.  This is how placeholder entries from "NewResumeData()" look.
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(value.String()) == ""
+	case reflect.Slice, reflect.Array:
+		for index := 0; index < value.Len(); index++ {
+			if !isEmptyValue(value.Index(index)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		return value.Len() == 0
+	case reflect.Struct:
+		for index := 0; index < value.NumField(); index++ {
+			if value.Type().Field(index).PkgPath == "" && !isEmptyValue(value.Field(index)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil() || isEmptyValue(value.Elem())
+	default:
+		return value.IsZero()
+	}
+}
@@ -0,0 +1,81 @@
+package data_test
+
+import (
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"testing"
+)
+
+// findIssue returns the validation issue with a given path and rule, or nil if there isn't one.
+func findIssue(issues []data.Issue, path, rule string) *data.Issue {
+	for index := range issues {
+		if issues[index].Path == path && issues[index].Rule == rule {
+			return &issues[index]
+		}
+	}
+	return nil
+}
+
+func TestValidateClean(t *testing.T) {
+	issues := data.Validate(testutils.GenerateTestResumeData())
+	if len(issues) > 0 {
+		t.Fatalf("Expected no validation issues for the test resume data, found: %v", issues)
+	}
+}
+
+func TestValidateProblems(t *testing.T) {
+	resume := testutils.GenerateTestResumeData()
+	resume.Basics.Email = "peter.gibbons at initech"
+	resume.Basics.Profiles[0].Url = "linkedin/peter.gibbons"
+	resume.Work[0].Company = ""
+	resume.Work[0].StartDate = "02/01/1998"
+	resume.AdditionalWork[0].EndDate = "1992"
+	resume.Education[0].EndDate = "1993-09"
+	resume.Skills = append(resume.Skills, data.Skill{Keywords: []string{""}})
+
+	issues := data.Validate(resume)
+	expected := []struct {
+		path     string
+		rule     string
+		severity data.Severity
+	}{
+		{"/basics/email", data.RuleEmail, data.SeverityWarning},
+		{"/basics/profiles/0/url", data.RuleUrl, data.SeverityWarning},
+		{"/work/0/company", data.RuleRequired, data.SeverityError},
+		{"/work/0/startDate", data.RuleDateFormat, data.SeverityError},
+		{"/additionalWork/0/endDate", data.RuleDateRange, data.SeverityError},
+		{"/skills/2", data.RuleEmptyEntry, data.SeverityWarning},
+	}
+	for _, e := range expected {
+		issue := findIssue(issues, e.path, e.rule)
+		if issue == nil {
+			t.Fatalf("Expected a %s issue at %s, found: %v", e.rule, e.path, issues)
+		}
+		if issue.Severity != e.severity {
+			t.Fatalf("Expected %s severity for %s, found %s", e.severity, e.path, issue.Severity)
+		}
+	}
+	// An end date sharing the start date's month is not out of order
+	if issue := findIssue(issues, "/education/0/endDate", data.RuleDateRange); issue != nil {
+		t.Fatalf("Unexpected date range issue: %v", issue)
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("Expected %d validation issues, found: %v", len(expected), issues)
+	}
+	if !data.HasErrors(issues) {
+		t.Fatal("Expected validation issues to include errors")
+	}
+}
+
+func TestValidatePlaceholders(t *testing.T) {
+	issues := data.Validate(data.NewResumeData())
+	if findIssue(issues, "/work/0", data.RuleEmptyEntry) == nil {
+		t.Fatalf("Expected placeholder work entry to be flagged, found: %v", issues)
+	}
+	if findIssue(issues, "/work/0/company", data.RuleRequired) != nil {
+		t.Fatal("Placeholder entries should not be checked for required fields")
+	}
+	if findIssue(issues, "/basics/name", data.RuleRequired) == nil {
+		t.Fatal("Expected missing name to be flagged")
+	}
+}
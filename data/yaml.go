@@ -0,0 +1,277 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FromYamlString loads a ResumeData struct from a string of YAML text.  YAML files use the same field names as JSON
+// files, and multi-line text (e.g. a long summary) may be written with block scalars ("|" or ">").
+func FromYamlString(yamlString string) (ResumeData, error) {
+	return FromYamlReader(strings.NewReader(yamlString))
+}
+
+// FromYamlFile loads a ResumeData struct from a YAML file.
+func FromYamlFile(yamlFilename string) (ResumeData, error) {
+	return readFile(yamlFilename, FromYamlReader)
+}
+
+// FromYamlReader loads a ResumeData struct from a stream of YAML text.  This provides the core logic for
+// `FromYamlString` and `FromYamlFile`.
+//
+// Rather than maintaining a third set of field tags, the YAML is parsed into generic values and then run through
+// the JSON decoding logic.  This way, YAML files get the same handling of dates, highlights and extra fields.
+func FromYamlReader(reader io.Reader) (ResumeData, error) {
+	yamlBytes, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return ResumeData{}, err
+	}
+	document, err := decodeYamlDocument(yamlBytes)
+	if err != nil {
+		return ResumeData{}, err
+	}
+	if document == nil {
+		return ResumeData{}, nil
+	}
+	jsonBytes, err := json.Marshal(coerceGeneric(document, resumeDataType))
+	if err != nil {
+		return ResumeData{}, err
+	}
+	return FromJsonReader(bytes.NewReader(jsonBytes))
+}
+
+// yamlScalar is an unquoted YAML scalar that resolved to something other than a string (e.g. "0123456" as an octal
+// number, or "NO" as a boolean), along with the text as written.  "coerceGeneric()" uses the text wherever a string
+// field is expected, so that hand-written values such as phone numbers, postal codes and country codes are kept
+// exactly.
+type yamlScalar struct {
+	Value interface{}
+	Text  string
+}
+
+// yamlNode captures a YAML value in the generic form, with "yamlScalar" in place of non-string scalars.
+type yamlNode struct {
+	value interface{}
+}
+
+func (node *yamlNode) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var generic interface{}
+	if err := unmarshal(&generic); err != nil {
+		return err
+	}
+	switch generic.(type) {
+	case nil, string:
+		node.value = generic
+	case map[interface{}]interface{}:
+		// Keys are decoded as strings, which likewise keeps them as written
+		var mapping map[string]yamlNode
+		if err := unmarshal(&mapping); err != nil {
+			return err
+		}
+		converted := make(map[string]interface{}, len(mapping))
+		for key, item := range mapping {
+			converted[key] = item.value
+		}
+		node.value = converted
+	case []interface{}:
+		var sequence []yamlNode
+		if err := unmarshal(&sequence); err != nil {
+			return err
+		}
+		converted := make([]interface{}, len(sequence))
+		for index, item := range sequence {
+			converted[index] = item.value
+		}
+		node.value = converted
+	default:
+		// "gopkg.in/yaml.v2" decodes a scalar into a string as the text written, whatever it resolves to
+		var text string
+		if err := unmarshal(&text); err != nil {
+			return err
+		}
+		node.value = yamlScalar{Value: generic, Text: text}
+	}
+	return nil
+}
+
+// decodeYamlDocument parses YAML text into the generic form, ready for "coerceGeneric()".
+func decodeYamlDocument(yamlBytes []byte) (interface{}, error) {
+	var document yamlNode
+	if err := yaml.Unmarshal(yamlBytes, &document); err != nil {
+		return nil, err
+	}
+	return document.value, nil
+}
+
+// coerceGeneric converts a generic value (e.g. as parsed by "gopkg.in/yaml.v2" or "github.com/BurntSushi/toml") into
+// the generic form used by "encoding/json", walking alongside a struct type such as ResumeData.  Map keys are
+// converted to strings, TOML dates are converted to text, and unquoted YAML scalars are converted back to the text
+// as written wherever a string field is expected (e.g. "startDate: 2014" or "postalCode: 02134").  Likewise, text is
+// converted to numbers and booleans where those are expected, and a single value is wrapped in a list where a list
+// is expected.  A nil type means that the value is an extra field, and is converted without coercion.
+func coerceGeneric(value interface{}, valueType reflect.Type) interface{} {
+	for valueType != nil && valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+	if valueType != nil && valueType.Kind() == reflect.Slice && value != nil {
+		switch value.(type) {
+		case []interface{}, []map[string]interface{}:
+		default:
+			value = []interface{}{value}
+		}
+	}
+	switch typed := value.(type) {
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			converted[fmt.Sprint(key)] = item
+		}
+		return coerceGeneric(converted, valueType)
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			var fieldType reflect.Type
+			if valueType != nil && valueType.Kind() == reflect.Struct {
+				if field, ok := structField(valueType, "json", key); ok {
+					fieldType = field.Type
+				}
+			}
+			converted[key] = coerceGeneric(item, fieldType)
+		}
+		return converted
+	case []map[string]interface{}:
+		converted := make([]interface{}, len(typed))
+		for index, item := range typed {
+			converted[index] = item
+		}
+		return coerceGeneric(converted, valueType)
+	case []interface{}:
+		var itemType reflect.Type
+		if valueType != nil && valueType.Kind() == reflect.Slice {
+			itemType = valueType.Elem()
+		}
+		converted := make([]interface{}, len(typed))
+		for index, item := range typed {
+			converted[index] = coerceGeneric(item, itemType)
+		}
+		return converted
+	case nil:
+		return typed
+	case string:
+		if valueType != nil {
+			switch valueType.Kind() {
+			case reflect.Bool:
+				if parsed, err := strconv.ParseBool(strings.TrimSpace(typed)); err == nil {
+					return parsed
+				}
+			case reflect.Int, reflect.Int64, reflect.Int32:
+				if parsed, err := strconv.Atoi(strings.TrimSpace(typed)); err == nil {
+					return parsed
+				}
+			}
+		}
+		return typed
+	case time.Time:
+		return formatTomlTime(typed)
+	case yamlScalar:
+		if valueType != nil && (valueType.Kind() == reflect.String || valueType == highlightType) {
+			return typed.Text
+		}
+		return coerceGeneric(typed.Value, valueType)
+	}
+	// Scalars other than strings
+	if valueType != nil && (valueType.Kind() == reflect.String || valueType == highlightType) {
+		switch typed := value.(type) {
+		case float64:
+			return strconv.FormatFloat(typed, 'f', -1, 64)
+		case float32:
+			return strconv.FormatFloat(float64(typed), 'f', -1, 32)
+		}
+		return fmt.Sprint(value)
+	}
+	return value
+}
+
+// ToYamlString writes a ResumeData struct to a string of YAML text.
+func ToYamlString(data ResumeData) (string, error) {
+	return writeString(data, ToYamlWriter)
+}
+
+// ToYamlFile writes a ResumeData struct to a YAML file.
+func ToYamlFile(data ResumeData, yamlFilename string) error {
+	return writeFile(data, yamlFilename, ToYamlWriter)
+}
+
+// ToYamlWriter writes a ResumeData struct to a stream of YAML text.  This provides the core logic for `ToYamlString`
+// and `ToYamlFile`.  Multi-line strings are written as literal block scalars.
+func ToYamlWriter(data ResumeData, writer io.Writer) error {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	// Convert through ordered maps, so that fields are written in the same order as in JSON and XML files
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+	document, err := decodeOrdered(decoder)
+	if err != nil {
+		return err
+	}
+	yamlBytes, err := yaml.Marshal(document)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(yamlBytes)
+	return err
+}
+
+// decodeOrdered reads the next JSON value from a decoder, with objects converted to "yaml.MapSlice" so that their
+// key order is preserved.
+func decodeOrdered(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := yaml.MapSlice{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, yaml.MapItem{Key: key, Value: value})
+		}
+		_, err := decoder.Token()
+		return object, err
+	case json.Delim('['):
+		array := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err := decoder.Token()
+		return array, err
+	}
+	if number, ok := token.(json.Number); ok {
+		if integer, err := number.Int64(); err == nil {
+			return integer, nil
+		}
+		return number.Float64()
+	}
+	return token, nil
+}
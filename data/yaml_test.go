@@ -0,0 +1,214 @@
+package data_test
+
+import (
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/testutils"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestYamlConversion(t *testing.T) {
+	originalData := testutils.GenerateTestResumeData()
+
+	yaml, err := data.ToYamlString(originalData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromYamlData, err := data.FromYamlString(yaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(originalData, fromYamlData) {
+		t.Fatal("Resume data after YAML conversion doesn't match the original")
+	}
+}
+
+func TestXmlToYamlConversion(t *testing.T) {
+	originalData := testutils.GenerateTestResumeData()
+
+	xml, err := data.ToXmlString(originalData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromXmlData, err := data.FromXmlString(xml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	yaml, err := data.ToYamlString(fromXmlData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromYamlData, err := data.FromYamlString(yaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(originalData, fromYamlData) {
+		t.Fatal("Resume data after XML-to-YAML conversion doesn't match the original")
+	}
+}
+
+func TestYamlToJsonConversion(t *testing.T) {
+	originalData := testutils.GenerateTestResumeData()
+
+	yaml, err := data.ToYamlString(originalData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromYamlData, err := data.FromYamlString(yaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	json, err := data.ToJsonString(fromYamlData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJsonData, err := data.FromJsonString(json)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(originalData, fromJsonData) {
+		t.Fatal("Resume data after YAML-to-JSON conversion doesn't match the original")
+	}
+}
+
+func TestYamlFileConversion(t *testing.T) {
+	yamlFilename := filepath.Join(os.TempDir(), "testresume.yaml")
+	testutils.DeleteFileIfExists(t, yamlFilename)
+	defer testutils.DeleteFileIfExists(t, yamlFilename)
+
+	originalData := testutils.GenerateTestResumeData()
+	err := data.ToYamlFile(originalData, yamlFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromYamlData, err := data.FromYamlFile(yamlFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(originalData, fromYamlData) {
+		t.Fatal("Resume data after YAML conversion doesn't match the original")
+	}
+}
+
+func TestYamlBlockScalars(t *testing.T) {
+	resume := data.ResumeData{Basics: data.Basics{Summary: "First paragraph.\n\nSecond paragraph."}}
+	yaml, err := data.ToYamlString(resume)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(yaml, "summary: |") {
+		t.Fatalf("Multi-line summary not written as a block scalar:\n%s", yaml)
+	}
+
+	fromYaml, err := data.FromYamlString(`basics:
+  name: Peter Gibbons
+  summary: >
+    Folded onto
+    a single line.
+work:
+  - company: Initech
+    highlights:
+      - |
+        Line one
+        Line two
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromYaml.Basics.Summary != "Folded onto a single line.\n" {
+		t.Fatalf("Unexpected folded summary: %q", fromYaml.Basics.Summary)
+	}
+	if fromYaml.Work[0].Highlights[0].Text != "Line one\nLine two\n" {
+		t.Fatalf("Unexpected literal highlight: %q", fromYaml.Work[0].Highlights[0].Text)
+	}
+}
+
+func TestYamlUnquotedScalars(t *testing.T) {
+	// Unquoted numbers and booleans are read as strings wherever the field is a string
+	resume, err := data.FromYamlString(`version: 1
+basics:
+  phone: 5555555555
+  location:
+    postalCode: 55555
+work:
+  - company: Initech
+    startDate: 1998
+    endDate: 1999-12-31
+    highlights:
+      - yes
+      - text: 3.5
+        tags: [backend]
+skills:
+  - name: Go
+    level: 10
+x-rating: 5
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resume.Version != 1 {
+		t.Fatalf("Unexpected version: %d", resume.Version)
+	}
+	if resume.Basics.Phone != "5555555555" || resume.Basics.Location.PostalCode != "55555" {
+		t.Fatalf("Unexpected basics: %+v", resume.Basics)
+	}
+	work := resume.Work[0]
+	if work.StartDate != "1998" || work.EndDate != "1999-12-31" {
+		t.Fatalf("Unexpected dates: %q to %q", work.StartDate, work.EndDate)
+	}
+	if work.Highlights[0].Text != "yes" || work.Highlights[1].Text != "3.5" || !work.Highlights[1].Tags.Has("backend") {
+		t.Fatalf("Unexpected highlights: %+v", work.Highlights)
+	}
+	if resume.Skills[0].Level != "10" {
+		t.Fatalf("Unexpected skill level: %q", resume.Skills[0].Level)
+	}
+	if resume.Extra["x-rating"] != float64(5) {
+		t.Fatalf("Unexpected extra field: %#v", resume.Extra["x-rating"])
+	}
+}
+
+func TestYamlUnquotedScalars_AsWritten(t *testing.T) {
+	// YAML 1.1 reads these as octal numbers, booleans and floats, but string fields keep the text as written
+	resume, err := data.FromYamlString(`basics:
+  phone: 0123456
+  location:
+    postalCode: 02134
+    countryCode: NO
+education:
+  - institution: University of Austin
+    gpa: 3.50
+x-zip: 02134
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resume.Basics.Phone != "0123456" || resume.Basics.Location.PostalCode != "02134" || resume.Basics.Location.CountryCode != "NO" {
+		t.Fatalf("Unexpected basics: %+v", resume.Basics)
+	}
+	if resume.Education[0].GPA != "3.50" {
+		t.Fatalf("Unexpected GPA: %q", resume.Education[0].GPA)
+	}
+	// Extra fields have no type to go by, and so keep the YAML value
+	if resume.Extra["x-zip"] != float64(1116) {
+		t.Fatalf("Unexpected extra field: %#v", resume.Extra["x-zip"])
+	}
+
+	// Writing quotes the values, so that they load back unchanged
+	yaml, err := data.ToYamlString(resume)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromYaml, err := data.FromYamlString(yaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resume, fromYaml) {
+		t.Fatalf("Resume data doesn't match after YAML conversion:\n%s", yaml)
+	}
+}
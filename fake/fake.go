@@ -0,0 +1,362 @@
+// Package fake generates random but realistic resume data, for stress-testing templates and for tests that need
+// data of varying size and content.
+package fake
+
+import (
+	"fmt"
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"math/rand"
+	"strings"
+	"unicode/utf8"
+)
+
+// Range is an inclusive range of sizes for "GeneratorOptions".  The zero value always generates zero.
+type Range struct {
+	Min int
+	Max int
+}
+
+func (r Range) pick(random *rand.Rand) int {
+	if r.Max <= r.Min {
+		return r.Min
+	}
+	return r.Min + random.Intn(r.Max-r.Min+1)
+}
+
+// GeneratorOptions controls the fake resume data produced by "GenerateResumeData()".  Start from
+// "NewGeneratorOptions()" and adjust the fields of interest, since the zero value of each Range generates nothing.
+type GeneratorOptions struct {
+	// Seed makes the output deterministic:  the same seed and options always generate the same resume data.
+	Seed int64
+	// Work, Education, Skills, Publications, Projects and Languages are the number of entries in each section.  Skills
+	// and Languages never repeat a name, and so are limited to the number of names available.
+	Work         Range
+	Education    Range
+	Skills       Range
+	Publications Range
+	Projects     Range
+	Languages    Range
+	// Roles is the number of positions held at each employer.  Entries with fewer than two roles have none listed.
+	Roles Range
+	// Highlights is the number of highlights for each work entry or role.
+	Highlights Range
+	// NameLength is the length in characters of company, institution and publication names.
+	NameLength Range
+	// TextLength is the length in words of summaries.  Highlights are half as long.
+	TextLength Range
+	// Locales picks the language of personal names and places, from "en", "de", "fr", "ru", "ja" and "ar".  Each
+	// resume uses one locale, chosen at random from the list.  An empty list, or an unknown locale, means "en".
+	Locales []string
+	// MissingFields is the probability (from 0 to 1) that each optional field is left blank.
+	MissingFields float64
+}
+
+// NewGeneratorOptions returns options producing resumes of typical size, with every supported locale and a few
+// missing fields.
+func NewGeneratorOptions(seed int64) GeneratorOptions {
+	return GeneratorOptions{
+		Seed:          seed,
+		Work:          Range{Min: 1, Max: 6},
+		Education:     Range{Min: 0, Max: 3},
+		Skills:        Range{Min: 0, Max: 6},
+		Publications:  Range{Min: 0, Max: 3},
+		Projects:      Range{Min: 0, Max: 3},
+		Languages:     Range{Min: 0, Max: 3},
+		Roles:         Range{Min: 1, Max: 3},
+		Highlights:    Range{Min: 0, Max: 4},
+		NameLength:    Range{Min: 6, Max: 40},
+		TextLength:    Range{Min: 10, Max: 60},
+		Locales:       []string{"en", "de", "fr", "ru", "ja", "ar"},
+		MissingFields: 0.1,
+	}
+}
+
+type generatorLocale struct {
+	givenNames  []string
+	familyNames []string
+	// cities are listed as "city|region|country code"
+	cities    []string
+	languages []string
+}
+
+var generatorLocales = map[string]generatorLocale{
+	"en": {
+		givenNames:  []string{"James", "Mary", "Robert", "Patricia", "Michael", "Jennifer", "Samir", "Lakshmi"},
+		familyNames: []string{"Smith", "Johnson", "O'Brien", "Williams", "Brown", "Nakamura-Jones", "Miller"},
+		cities:      []string{"Austin|TX|US", "Portland|OR|US", "Leeds||GB", "Toronto|ON|CA"},
+		languages:   []string{"English", "Spanish", "Hindi"},
+	},
+	"de": {
+		givenNames:  []string{"Jürgen", "Käthe", "Günther", "Björn", "Lütfiye", "Maximilian-Alexander"},
+		familyNames: []string{"Müller", "Schröder", "Weiß", "Groß", "von Hohenzollern-Sigmaringen"},
+		cities:      []string{"München|BY|DE", "Köln|NW|DE", "Zürich|ZH|CH", "Wien||AT"},
+		languages:   []string{"Deutsch", "Englisch", "Französisch"},
+	},
+	"fr": {
+		givenNames:  []string{"François", "Hélène", "Zoë", "Jérôme", "Anaïs", "Noël"},
+		familyNames: []string{"Lefèvre", "Côté", "Dubois", "Bénard", "d'Aubigné"},
+		cities:      []string{"Montréal|QC|CA", "Paris||FR", "Genève|GE|CH", "Québec|QC|CA"},
+		languages:   []string{"Français", "Anglais", "Espagnol"},
+	},
+	"ru": {
+		givenNames:  []string{"Алексей", "Наталья", "Дмитрий", "Екатерина", "Юрий"},
+		familyNames: []string{"Иванов", "Смирнова", "Кузнецов", "Достоевская"},
+		cities:      []string{"Москва||RU", "Санкт-Петербург||RU", "Новосибирск||RU"},
+		languages:   []string{"Русский", "Английский", "Немецкий"},
+	},
+	"ja": {
+		givenNames:  []string{"翔太", "美咲", "健一", "さくら", "大輔"},
+		familyNames: []string{"佐藤", "鈴木", "高橋", "田中", "渡辺"},
+		cities:      []string{"東京||JP", "大阪||JP", "札幌||JP"},
+		languages:   []string{"日本語", "英語", "中国語"},
+	},
+	"ar": {
+		givenNames:  []string{"محمد", "فاطمة", "أحمد", "ليلى", "يوسف"},
+		familyNames: []string{"الحسن", "العلي", "المصري", "الخطيب"},
+		cities:      []string{"القاهرة||EG", "عمّان||JO", "دبي||AE"},
+		languages:   []string{"العربية", "الإنجليزية", "الفرنسية"},
+	},
+}
+
+var (
+	generatorCompanyWords = []string{"Initech", "Acme", "Vandelay", "Dunder", "Prestige", "Globex", "Umbrella",
+		"Soylent", "Cyberdyne", "Tyrell", "Hooli", "Massive", "Dynamic", "Pacific", "Northern", "Industries",
+		"Systems", "Holdings", "Solutions", "Logistics", "International", "Worldwide", "Consulting", "Labs", "Group"}
+	generatorInstitutionWords = []string{"University", "of", "Austin", "Technical", "Institute", "State", "College",
+		"Polytechnic", "Academy", "Saint", "Mary's", "Northern", "Lakes", "School", "Arts", "Sciences"}
+	generatorPositions = []string{"Software Developer", "Senior Software Developer", "Team Lead", "Engineering Manager",
+		"Data Analyst", "Accountant", "Technical Writer", "Principal Architect", "Intern", "Director of Operations"}
+	generatorSkills = []string{"Go", "Java", "Python", "SQL", "Kubernetes", "Leadership", "Public Speaking",
+		"Technical Writing", "Accounting", "Negotiation", "C++", "Machine Learning"}
+	generatorWords = []string{"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed",
+		"do", "eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore", "magna", "aliqua", "enim", "ad",
+		"minim", "veniam", "quis", "nostrud", "exercitation", "ullamco", "laboris", "nisi", "aliquip", "commodo"}
+	generatorDegrees  = []string{"B.S.", "B.A.", "M.S.", "MBA", "Ph.D.", "Diploma"}
+	generatorAreas    = []string{"Computer Science", "Mathematics", "Economics", "English Literature", "Physics"}
+	generatorFluency  = []string{"Native speaker", "Fluent", "Conversational", "Basic"}
+	generatorLevels   = []string{"Beginner", "Intermediate", "Advanced", "Expert"}
+	generatorNetworks = []string{"LinkedIn", "GitHub", "Twitter", "Mastodon"}
+)
+
+// generator holds the state for producing one resume.
+type generator struct {
+	random  *rand.Rand
+	options GeneratorOptions
+	locale  generatorLocale
+}
+
+// GenerateResumeData builds a fake but realistic resume, for stress-testing templates with data that varies in
+// size, text length, alphabet (including right-to-left scripts) and completeness.  Unlike
+// "testutils.GenerateTestResumeData()", whose fixed content tests can rely on, the content here is random... but
+// deterministic for a given seed.
+func GenerateResumeData(options GeneratorOptions) data.ResumeData {
+	random := rand.New(rand.NewSource(options.Seed))
+	locales := options.Locales
+	if len(locales) == 0 {
+		locales = []string{"en"}
+	}
+	locale, ok := generatorLocales[locales[random.Intn(len(locales))]]
+	if !ok {
+		locale = generatorLocales["en"]
+	}
+	gen := generator{random: random, options: options, locale: locale}
+	return gen.resume()
+}
+
+func (gen *generator) resume() data.ResumeData {
+	name := gen.pick(gen.locale.givenNames) + " " + gen.pick(gen.locale.familyNames)
+	handle := fmt.Sprintf("user%d", gen.random.Intn(100000))
+	city := strings.Split(gen.pick(gen.locale.cities), "|")
+	resume := data.ResumeData{
+		Version: data.SCHEMA_VERSION,
+		Basics: data.Basics{
+			Name:    name,
+			Label:   gen.optional(gen.pick(generatorPositions)),
+			Picture: gen.optional("https://example.com/photos/" + handle + ".jpg"),
+			Email:   gen.optional(handle + "@example.com"),
+			Phone:   gen.optional(fmt.Sprintf("+1 555-%03d-%04d", gen.random.Intn(1000), gen.random.Intn(10000))),
+			Website: gen.optional("https://" + handle + ".example.com"),
+			Summary: gen.optional(gen.text(gen.options.TextLength)),
+			Location: data.Location{
+				Address:     gen.optional(fmt.Sprintf("%d %s Street", 1+gen.random.Intn(9999), gen.pick(gen.locale.familyNames))),
+				PostalCode:  gen.optional(fmt.Sprintf("%05d", gen.random.Intn(100000))),
+				City:        gen.optional(city[0]),
+				Region:      gen.optional(city[1]),
+				CountryCode: gen.optional(city[2]),
+			},
+		},
+	}
+	for _, network := range generatorNetworks {
+		if gen.chance(0.5) {
+			resume.Basics.Profiles = append(resume.Basics.Profiles, data.SocialProfile{
+				Network:  network,
+				Username: handle,
+				Url:      gen.optional("https://" + strings.ToLower(network) + ".example.com/" + handle),
+			})
+		}
+	}
+
+	// The career runs backward from a fixed date, rather than the current one, so that output is deterministic
+	latest := 2024*12 + gen.random.Intn(12)
+	month := latest
+	for index, count := 0, gen.options.Work.pick(gen.random); index < count; index++ {
+		var work data.Work
+		work, month = gen.work(month, index == 0)
+		resume.Work = append(resume.Work, work)
+	}
+	for index, count := 0, gen.options.Education.pick(gen.random); index < count; index++ {
+		month -= 12 * (2 + gen.random.Intn(3))
+		resume.Education = append(resume.Education, data.Education{
+			Institution: gen.name(generatorInstitutionWords),
+			Area:        gen.optional(gen.pick(generatorAreas)),
+			StudyType:   gen.optional(gen.pick(generatorDegrees)),
+			StartDate:   gen.date(month, true),
+			EndDate:     gen.date(month+12*(2+gen.random.Intn(3)), true),
+			GPA:         gen.optional(fmt.Sprintf("%.1f", 2+gen.random.Float64()*2)),
+		})
+	}
+	// Skill names are picked without replacement, so that none is listed twice
+	skillOrder := gen.random.Perm(len(generatorSkills))
+	for index, count := 0, gen.options.Skills.pick(gen.random); index < count && index < len(skillOrder); index++ {
+		resume.Skills = append(resume.Skills, data.Skill{
+			Name:     generatorSkills[skillOrder[index]],
+			Level:    gen.optional(gen.pick(generatorLevels)),
+			Keywords: gen.words(gen.random.Intn(5)),
+		})
+	}
+	for index, count := 0, gen.options.Publications.pick(gen.random); index < count; index++ {
+		resume.Publications = append(resume.Publications, data.Publication{
+			Name:        gen.name(generatorWords),
+			Publisher:   gen.optional(gen.name(generatorCompanyWords)),
+			ReleaseDate: gen.date(month+gen.random.Intn(latest-month+1), false),
+			Website:     gen.optional(fmt.Sprintf("https://example.com/publications/%d", gen.random.Intn(10000))),
+			Summary:     gen.optional(gen.text(gen.options.TextLength)),
+		})
+	}
+	for index, count := 0, gen.options.Projects.pick(gen.random); index < count; index++ {
+		resume.Projects = append(resume.Projects, data.Project{
+			Name:        gen.name(generatorWords),
+			Description: gen.optional(gen.text(gen.options.TextLength)),
+			Highlights:  gen.highlightTexts(),
+			Keywords:    gen.words(gen.random.Intn(4)),
+			StartDate:   gen.date(month+gen.random.Intn(latest-month+1), false),
+			Url:         gen.optional(fmt.Sprintf("https://example.com/projects/%d", gen.random.Intn(10000))),
+		})
+	}
+	for index, count := 0, gen.options.Languages.pick(gen.random); index < count && index < len(gen.locale.languages); index++ {
+		resume.Languages = append(resume.Languages, data.Language{
+			Language: gen.locale.languages[index],
+			Fluency:  gen.optional(gen.pick(generatorFluency)),
+		})
+	}
+	return resume
+}
+
+// work generates an employer whose tenure ends at the given month (counted from year zero), returning it along with
+// the month that the tenure started.  The most recent employer may be the current one, with no end date.
+func (gen *generator) work(end int, mostRecent bool) (data.Work, int) {
+	work := data.Work{
+		Company: gen.name(generatorCompanyWords),
+		Website: gen.optional(fmt.Sprintf("https://company%d.example.com", gen.random.Intn(10000))),
+		Summary: gen.optional(gen.text(gen.options.TextLength)),
+	}
+	current := mostRecent && gen.chance(0.5)
+	roleCount := gen.options.Roles.pick(gen.random)
+	if roleCount < 2 {
+		start := end - 6 - gen.random.Intn(66)
+		work.Position = gen.pick(generatorPositions)
+		work.StartDate = gen.date(start, true)
+		if !current {
+			work.EndDate = gen.date(end, true)
+		}
+		work.Highlights = gen.highlights()
+		return work, start - gen.random.Intn(6)
+	}
+	for index := 0; index < roleCount; index++ {
+		start := end - 6 - gen.random.Intn(42)
+		role := data.Role{
+			Title:      gen.pick(generatorPositions),
+			StartDate:  gen.date(start, true),
+			Summary:    gen.optional(gen.text(gen.options.TextLength)),
+			Highlights: gen.highlights(),
+		}
+		if index > 0 || !current {
+			role.EndDate = gen.date(end, true)
+		}
+		work.Roles = append(work.Roles, role)
+		end = start - 1
+	}
+	return work, end - gen.random.Intn(6)
+}
+
+func (gen *generator) highlights() []data.Highlight {
+	var highlights []data.Highlight
+	for _, text := range gen.highlightTexts() {
+		highlights = append(highlights, data.Highlight{Text: text})
+	}
+	return highlights
+}
+
+func (gen *generator) highlightTexts() []string {
+	var texts []string
+	length := Range{Min: (gen.options.TextLength.Min + 1) / 2, Max: (gen.options.TextLength.Max + 1) / 2}
+	for index, count := 0, gen.options.Highlights.pick(gen.random); index < count; index++ {
+		texts = append(texts, gen.text(length))
+	}
+	return texts
+}
+
+// date formats a month (counted from year zero) as a JSON-Resume date, with either day or month precision.
+func (gen *generator) date(month int, withDay bool) data.Date {
+	if withDay {
+		return data.Date(fmt.Sprintf("%04d-%02d-01", month/12, month%12+1))
+	}
+	return data.Date(fmt.Sprintf("%04d-%02d", month/12, month%12+1))
+}
+
+// name joins random words until reaching a length from "NameLength", and then trims it to exactly that length.
+func (gen *generator) name(words []string) string {
+	length := gen.options.NameLength.pick(gen.random)
+	if length < 1 {
+		length = 1
+	}
+	name := gen.pick(words)
+	for utf8.RuneCountInString(name) < length {
+		name += " " + gen.pick(words)
+	}
+	return strings.TrimSpace(string([]rune(name)[:length]))
+}
+
+// text generates a sentence with a word count from the given range.
+func (gen *generator) text(length Range) string {
+	words := gen.words(length.pick(gen.random))
+	if len(words) == 0 {
+		return ""
+	}
+	words[0] = strings.ToUpper(words[0][:1]) + words[0][1:]
+	return strings.Join(words, " ") + "."
+}
+
+func (gen *generator) words(count int) []string {
+	var words []string
+	for index := 0; index < count; index++ {
+		words = append(words, gen.pick(generatorWords))
+	}
+	return words
+}
+
+func (gen *generator) pick(list []string) string {
+	return list[gen.random.Intn(len(list))]
+}
+
+func (gen *generator) chance(probability float64) bool {
+	return gen.random.Float64() < probability
+}
+
+// optional returns the value, or blank with the probability given by "MissingFields".
+func (gen *generator) optional(value string) string {
+	if gen.chance(gen.options.MissingFields) {
+		return ""
+	}
+	return value
+}
@@ -0,0 +1,102 @@
+package fake_test
+
+import (
+	"gitlab.com/steve-perkins/ResumeFodder/data"
+	"gitlab.com/steve-perkins/ResumeFodder/fake"
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGenerateResumeData_Deterministic(t *testing.T) {
+	options := fake.NewGeneratorOptions(42)
+	first := fake.GenerateResumeData(options)
+	if !reflect.DeepEqual(first, fake.GenerateResumeData(options)) {
+		t.Fatal("Expected the same seed to generate the same resume data")
+	}
+	options.Seed = 43
+	if reflect.DeepEqual(first, fake.GenerateResumeData(options)) {
+		t.Fatal("Expected a different seed to generate different resume data")
+	}
+}
+
+func TestGenerateResumeData_Ranges(t *testing.T) {
+	options := fake.NewGeneratorOptions(0)
+	options.Work = fake.Range{Min: 2, Max: 4}
+	options.Skills = fake.Range{Min: 1, Max: 5}
+	options.Roles = fake.Range{Min: 2, Max: 3}
+	options.NameLength = fake.Range{Min: 10, Max: 20}
+	checkRange := func(seed int64, section string, count int, expected fake.Range) {
+		if count < expected.Min || count > expected.Max {
+			t.Fatalf("Seed %d generated %d %s entries, outside of %+v", seed, count, section, expected)
+		}
+	}
+	for seed := int64(0); seed < 50; seed++ {
+		options.Seed = seed
+		resume := fake.GenerateResumeData(options)
+		checkRange(seed, "work", len(resume.Work), options.Work)
+		checkRange(seed, "education", len(resume.Education), options.Education)
+		checkRange(seed, "skills", len(resume.Skills), options.Skills)
+		checkRange(seed, "publications", len(resume.Publications), options.Publications)
+		checkRange(seed, "projects", len(resume.Projects), options.Projects)
+		checkRange(seed, "languages", len(resume.Languages), options.Languages)
+		for _, work := range resume.Work {
+			checkRange(seed, "role", len(work.Roles), options.Roles)
+			// Trailing spaces are trimmed, and so a name may come up one character short
+			checkRange(seed, "company name character", utf8.RuneCountInString(work.Company)+1,
+				fake.Range{Min: options.NameLength.Min, Max: options.NameLength.Max + 1})
+		}
+		names := map[string]bool{}
+		for _, skill := range resume.Skills {
+			if names[skill.Name] {
+				t.Fatalf("Seed %d generated the skill %q twice", seed, skill.Name)
+			}
+			names[skill.Name] = true
+		}
+	}
+}
+
+func TestGenerateResumeData_Locales(t *testing.T) {
+	options := fake.NewGeneratorOptions(42)
+	options.MissingFields = 0
+	cities := map[string][]string{
+		"ja": {"東京", "大阪", "札幌"},
+		// An unknown locale falls back to English
+		"xx": {"Austin", "Portland", "Leeds", "Toronto"},
+	}
+	for locale, expected := range cities {
+		options.Locales = []string{locale}
+		for seed := int64(0); seed < 10; seed++ {
+			options.Seed = seed
+			city := fake.GenerateResumeData(options).Basics.Location.City
+			found := false
+			for _, name := range expected {
+				found = found || city == name
+			}
+			if !found {
+				t.Fatalf("Unexpected city for the %q locale: %s", locale, city)
+			}
+		}
+	}
+}
+
+func TestGenerateResumeData_MissingFields(t *testing.T) {
+	options := fake.NewGeneratorOptions(42)
+	options.MissingFields = 0
+	complete := fake.GenerateResumeData(options)
+	basics := complete.Basics
+	if basics.Label == "" || basics.Email == "" || basics.Phone == "" || basics.Website == "" || basics.Summary == "" ||
+		basics.Location.City == "" || basics.Location.PostalCode == "" || basics.Location.CountryCode == "" {
+		t.Fatalf("Expected no missing fields: %+v", basics)
+	}
+
+	options.MissingFields = 1
+	sparse := fake.GenerateResumeData(options)
+	if !reflect.DeepEqual(sparse.Basics.Location, data.Location{}) || sparse.Basics.Email != "" || sparse.Basics.Summary != "" {
+		t.Fatalf("Expected every optional field to be missing: %+v", sparse.Basics)
+	}
+	// Required fields are always filled in
+	if sparse.Basics.Name == "" || len(sparse.Work) == 0 || sparse.Work[0].Company == "" {
+		t.Fatalf("Expected the required fields to be filled in: %+v", sparse)
+	}
+}
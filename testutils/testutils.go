@@ -32,6 +32,9 @@ func GenerateTestResumeData() data.ResumeData {
 					Url:      "http://linkedin.com/peter.gibbons",
 				},
 			},
+			Extra: data.Extra{
+				"x-nickname": "Pete",
+			},
 		},
 		Work: []data.Work{
 			{
@@ -39,10 +42,27 @@ func GenerateTestResumeData() data.ResumeData {
 				Position:  "Software Developer",
 				StartDate: "1998-02-01",
 				Summary:   "Deals with the customers so the engineers don't have to.  A people person, damn it!",
-				Highlights: []string{
-					"Identifying Y2K-related issues in application code.",
-					"As many as four people working right underneath me.",
+				Highlights: []data.Highlight{
+					{Text: "Identifying Y2K-related issues in application code.", Tags: data.Tags{"backend"}},
+					{Text: "As many as four people working right underneath me.", Tags: data.Tags{"management"}},
+				},
+				Roles: []data.Role{
+					{
+						Title:     "Software Developer",
+						StartDate: "1998-02-01",
+						EndDate:   "1999-06-30",
+						Summary:   "Updated bank software for the 2000 switch.",
+						Highlights: []data.Highlight{
+							{Text: "Filed TPS reports with the new cover sheet.", Tags: data.Tags{"backend"}},
+						},
+					},
+					{
+						Title:     "Senior Software Developer",
+						StartDate: "1999-07-01",
+						Summary:   "Led the Y2K remediation effort.",
+					},
 				},
+				Tags: data.Tags{"backend", "management"},
 			},
 		},
 		WorkLabel: "Professional Experience",
@@ -53,13 +73,43 @@ func GenerateTestResumeData() data.ResumeData {
 				StartDate: "1993-08-01",
 				EndDate:   "1998-01-31",
 				Summary:   "Paying my way through school with an exciting opportunity in the fast-food service industry.",
-				Highlights: []string{
-					"Wore 37 pieces of flair.",
-					"A terrific smile.",
+				Highlights: []data.Highlight{
+					{Text: "Wore 37 pieces of flair."},
+					{Text: "A terrific smile."},
 				},
 			},
 		},
 		AdditionalWorkLabel: "Academic Work Experience",
+		WorkGroups: []data.WorkGroup{
+			{
+				Name: "Consulting",
+				Work: []data.Work{
+					{
+						Company:   "The Bobs",
+						Position:  "Efficiency Consultant",
+						StartDate: "1999-05",
+						EndDate:   "1999-06",
+						Summary:   "Helped Initech streamline operations, by asking what exactly it is that people do here.",
+						Highlights: []data.Highlight{
+							{Text: "Conducted one-on-one interviews with all staff."},
+						},
+					},
+				},
+			},
+		},
+		Volunteer: []data.Volunteer{
+			{
+				Organization: "Habitat for Humanity",
+				Position:     "Construction Volunteer",
+				Url:          "http://habitat.org",
+				StartDate:    "1999-03-01",
+				EndDate:      "1999-09-01",
+				Summary:      "Helped build a house, until the paper clips ran out.",
+				Highlights: []string{
+					"Drove a nail with nothing but a stapler.",
+				},
+			},
+		},
 		Education: []data.Education{
 			{
 				Institution: "University of Austin",
@@ -68,16 +118,34 @@ func GenerateTestResumeData() data.ResumeData {
 				EndDate:     "1997-12-01",
 			},
 		},
+		Awards: []data.Award{
+			{
+				Title:   "Employee of the Month",
+				Date:    "1998-11-01",
+				Awarder: "Initech",
+				Summary: "Awarded for consistently filing TPS reports with the new cover sheet.",
+			},
+		},
+		Certificates: []data.Certificate{
+			{
+				Name:   "Certified Y2K Remediation Specialist",
+				Date:   "1998-06-01",
+				Issuer: "Bobs Consulting",
+				Url:    "http://bobs.example.com/certs/y2k",
+			},
+		},
 		Skills: []data.Skill{
 			{
 				Name:     "Programming",
 				Level:    "Mid-level",
 				Keywords: []string{"C++", "Java"},
+				Tags:     data.Tags{"backend"},
 			},
 			{
 				Name:     "Communication",
 				Level:    "Junior",
 				Keywords: []string{"Verbal", "Written"},
+				Tags:     data.Tags{"management"},
 			},
 		},
 		Publications: []data.Publication{
@@ -98,6 +166,56 @@ func GenerateTestResumeData() data.ResumeData {
 			},
 		},
 		AdditionalPublicationsLabel: "Academic Publications",
+		PublicationGroups: []data.PublicationGroup{
+			{
+				Name: "Technical Reviewer",
+				Publications: []data.Publication{
+					{
+						Name:        "TPS Reports: The Definitive Guide",
+						Publisher:   "Initech Press",
+						ReleaseDate: "1999",
+					},
+				},
+			},
+		},
+		Languages: []data.Language{
+			{
+				Language: "English",
+				Fluency:  "Native speaker",
+			},
+			{
+				Language: "Spanish",
+				Fluency:  "Conversational",
+			},
+		},
+		Interests: []data.Interest{
+			{
+				Name:     "Fishing",
+				Keywords: []string{"Lake", "Deep sea"},
+			},
+		},
+		References: []data.Reference{
+			{
+				Name:      "Lawrence",
+				Reference: "Peter is my next-door neighbor, and a heck of a guy.",
+			},
+		},
+		Projects: []data.Project{
+			{
+				Name:        "Penny Shaving",
+				Description: "Side project to redirect rounding fractions into a private account.",
+				Highlights: []string{
+					"Moved a decimal point.",
+				},
+				Keywords:  []string{"C++", "Finance"},
+				StartDate: "1999-01-01",
+				EndDate:   "1999-02-01",
+				Url:       "http://initech.example.com/penny",
+				Roles:     []string{"Co-conspirator"},
+				Entity:    "Initech",
+				Type:      "application",
+			},
+		},
 	}
 	return data
 }